@@ -1,11 +1,40 @@
 package async
 
-import promise "github.com/eolme/go-promise/promise"
+import (
+	"context"
+	"runtime/pprof"
+
+	promise "github.com/eolme/go-promise/promise"
+)
 
 type AsyncFunction func() (any, error)
 
 func Async(fn AsyncFunction) *promise.Promise {
-	return promise.New(func(resolve promise.PromiseResolve, reject promise.PromiseReject) {
+	return promise.Go(fn)
+}
+
+func AsyncWithContext(ctx context.Context, fn AsyncFunction) *promise.Promise {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return promise.NewWithContext(ctx, func(resolve promise.PromiseResolve, reject promise.PromiseReject) {
+		go func() {
+			pprof.SetGoroutineLabels(ctx)
+
+			packed, reason := fn()
+
+			if reason != nil {
+				reject(reason)
+			} else {
+				resolve(packed)
+			}
+		}()
+	})
+}
+
+func Lazy(fn AsyncFunction) *promise.Promise {
+	return promise.Lazy(func(resolve promise.PromiseResolve, reject promise.PromiseReject) {
 		go func() {
 			packed, reason := fn()
 
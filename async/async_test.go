@@ -0,0 +1,35 @@
+package async_test
+
+import (
+	"context"
+	"runtime/pprof"
+	"testing"
+
+	async "github.com/eolme/go-promise/async"
+)
+
+func TestAsyncWithContext(t *testing.T) {
+	t.Parallel()
+
+	ctx := pprof.WithLabels(context.Background(), pprof.Labels("job", "import"))
+
+	result, reason := async.AsyncWithContext(ctx, func() (any, error) {
+		label, _ := pprof.Label(ctx, "job")
+
+		return label, nil
+	}).Wait()
+
+	assertEqual(t, result, "import")
+	assertEqual(t, reason, nil)
+}
+
+func TestAsyncWithNilContext(t *testing.T) {
+	t.Parallel()
+
+	result, reason := async.AsyncWithContext(nil, func() (any, error) {
+		return 1, nil
+	}).Wait()
+
+	assertEqual(t, result, 1)
+	assertEqual(t, reason, nil)
+}
@@ -0,0 +1,191 @@
+package async
+
+import (
+	"errors"
+	"sync"
+
+	promise "github.com/eolme/go-promise/promise"
+)
+
+var ErrIteratorReturned = errors.New("Iterator returned")
+
+type (
+	AsyncYield             = func(value any) *promise.Promise
+	AsyncGeneratorFunction func(yield AsyncYield) error
+	AsyncIteratorResult    struct {
+		Value any
+		Done  bool
+	}
+)
+
+type iteratorRequest struct {
+	resolve promise.PromiseResolve
+	reject  promise.PromiseReject
+}
+
+type AsyncIterator struct {
+	mutex    sync.Mutex
+	cond     *sync.Cond
+	fn       AsyncGeneratorFunction
+	queue    []iteratorRequest
+	ack      *iteratorRequest
+	started  bool
+	done     bool
+	returned bool
+	failure  error
+	finished *promise.Promise
+	finish   promise.PromiseResolve
+}
+
+func Generator(fn AsyncGeneratorFunction) (iterator *AsyncIterator) {
+	iterator = &AsyncIterator{
+		fn:    fn,
+		queue: []iteratorRequest{},
+	}
+
+	iterator.cond = sync.NewCond(&iterator.mutex)
+	iterator.finished = promise.New(func(resolve promise.PromiseResolve, _ promise.PromiseReject) {
+		iterator.finish = resolve
+	})
+
+	return iterator
+}
+
+func (self *AsyncIterator) Next() *promise.Promise {
+	return promise.New(func(resolve promise.PromiseResolve, reject promise.PromiseReject) {
+		self.mutex.Lock()
+		defer self.mutex.Unlock()
+
+		if self.done {
+			if self.failure != nil {
+				reject(self.failure)
+				self.failure = nil
+			} else {
+				resolve(AsyncIteratorResult{Done: true})
+			}
+
+			return
+		}
+
+		self.queue = append(self.queue, iteratorRequest{
+			resolve: resolve,
+			reject:  reject,
+		})
+
+		if self.ack != nil {
+			self.ack.resolve(nil)
+			self.ack = nil
+		}
+
+		if !self.started {
+			self.started = true
+			go self.run()
+		}
+
+		self.cond.Broadcast()
+	})
+}
+
+func (self *AsyncIterator) Return() *promise.Promise {
+	self.mutex.Lock()
+
+	self.returned = true
+	self.done = true
+	self.failure = nil
+
+	for _, request := range self.queue {
+		request.resolve(AsyncIteratorResult{Done: true})
+	}
+	self.queue = nil
+
+	if self.ack != nil {
+		self.ack.reject(ErrIteratorReturned)
+		self.ack = nil
+	}
+
+	if !self.started {
+		self.started = true
+		self.finish(nil)
+	}
+
+	self.cond.Broadcast()
+	self.mutex.Unlock()
+
+	return self.finished.Then(func(_ any) (any, error) {
+		return AsyncIteratorResult{Done: true}, nil
+	})
+}
+
+func (self *AsyncIterator) run() {
+	reason := self.fn(self.yield)
+
+	self.mutex.Lock()
+
+	self.done = true
+
+	for _, request := range self.queue {
+		if reason != nil && !self.returned {
+			request.reject(reason)
+			reason = nil
+		} else {
+			request.resolve(AsyncIteratorResult{Done: true})
+		}
+	}
+	self.queue = nil
+
+	if !self.returned {
+		self.failure = reason
+	}
+
+	self.mutex.Unlock()
+
+	self.finish(nil)
+}
+
+func (self *AsyncIterator) yield(value any) *promise.Promise {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	for len(self.queue) == 0 && !self.returned {
+		self.cond.Wait()
+	}
+
+	if self.returned {
+		return promise.Reject(ErrIteratorReturned)
+	}
+
+	request := self.queue[0]
+	self.queue = self.queue[1:]
+
+	request.resolve(AsyncIteratorResult{Value: value})
+
+	if len(self.queue) > 0 {
+		return promise.Resolve(nil)
+	}
+
+	return promise.New(func(resolve promise.PromiseResolve, reject promise.PromiseReject) {
+		self.ack = &iteratorRequest{
+			resolve: resolve,
+			reject:  reject,
+		}
+	})
+}
+
+func ForAwait(iterator *AsyncIterator, fn func(value any) error) error {
+	for {
+		packed, reason := iterator.Next().Wait()
+		if reason != nil {
+			return reason
+		}
+
+		result := packed.(AsyncIteratorResult)
+		if result.Done {
+			return nil
+		}
+
+		if reason := fn(result.Value); reason != nil {
+			iterator.Return().Wait()
+			return reason
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package async_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	async "github.com/eolme/go-promise/async"
+	promise "github.com/eolme/go-promise/promise"
+)
+
+func assertEqual(t *testing.T, actual any, expected any) {
+	if actual == expected {
+		t.Logf("Assertion success: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	} else {
+		t.Errorf("Assertion fail: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	}
+}
+
+func TestForAwait(t *testing.T) {
+	t.Parallel()
+
+	iterator := async.Generator(func(yield async.AsyncYield) error {
+		for index := 0; index < 3; index++ {
+			if _, reason := yield(index).Wait(); reason != nil {
+				return reason
+			}
+		}
+
+		return nil
+	})
+
+	sum := 0
+	reason := async.ForAwait(iterator, func(value any) error {
+		sum += value.(int)
+
+		return nil
+	})
+
+	assertEqual(t, reason, nil)
+	assertEqual(t, sum, 3)
+}
+
+func TestBackpressure(t *testing.T) {
+	t.Parallel()
+
+	produced := uint32(0)
+	iterator := async.Generator(func(yield async.AsyncYield) error {
+		for {
+			atomic.AddUint32(&produced, 1)
+
+			if _, reason := yield(nil).Wait(); reason != nil {
+				return reason
+			}
+		}
+	})
+
+	iterator.Next().Wait()
+	time.Sleep(50 * time.Millisecond)
+
+	assertEqual(t, atomic.LoadUint32(&produced), uint32(1))
+
+	packed, _ := iterator.Return().Wait()
+	assertEqual(t, packed.(async.AsyncIteratorResult).Done, true)
+
+	packed, _ = iterator.Next().Wait()
+	assertEqual(t, packed.(async.AsyncIteratorResult).Done, true)
+}
+
+func TestGeneratorError(t *testing.T) {
+	t.Parallel()
+
+	dummyReason := errors.New("failed")
+	iterator := async.Generator(func(yield async.AsyncYield) error {
+		yield(1)
+
+		return dummyReason
+	})
+
+	reason := async.ForAwait(iterator, func(_ any) error {
+		return nil
+	})
+
+	assertEqual(t, reason, dummyReason)
+
+	packed, _ := iterator.Next().Wait()
+	assertEqual(t, packed.(async.AsyncIteratorResult).Done, true)
+}
+
+func TestGeneratorPlainFunc(t *testing.T) {
+	t.Parallel()
+
+	var produce func(yield func(value any) *promise.Promise) error = func(yield func(value any) *promise.Promise) error {
+		_, reason := yield("plain").Wait()
+
+		return reason
+	}
+
+	packed, reason := async.Generator(produce).Next().Wait()
+
+	assertEqual(t, reason, nil)
+	assertEqual(t, packed.(async.AsyncIteratorResult).Value, "plain")
+}
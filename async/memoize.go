@@ -0,0 +1,44 @@
+package async
+
+import (
+	"math"
+	"time"
+
+	cache "github.com/eolme/go-promise/cache"
+	promise "github.com/eolme/go-promise/promise"
+)
+
+const keepForever = time.Duration(math.MaxInt64)
+
+type MemoizeOptions struct {
+	TTL           time.Duration
+	MaxEntries    int
+	EvictOnReject bool
+}
+
+func Memoize[K comparable, V any](fn func(key K) (V, error), options MemoizeOptions) func(key K) *promise.Promise {
+	return MemoizeBy(fn, func(key K) K {
+		return key
+	}, options)
+}
+
+func MemoizeBy[K any, H comparable, V any](fn func(key K) (V, error), hasher func(key K) H, options MemoizeOptions) func(key K) *promise.Promise {
+	negative := options.TTL
+	if options.EvictOnReject {
+		negative = 0
+	} else if negative <= 0 {
+		negative = keepForever
+	}
+
+	memo := cache.New[H](nil, cache.CacheOptions{
+		TTL:         options.TTL,
+		NegativeTTL: negative,
+		MaxEntries:  options.MaxEntries,
+	})
+
+	return func(key K) *promise.Promise {
+		return memo.GetOrLoad(hasher(key), func() (any, error) {
+			return fn(key)
+		})
+	}
+}
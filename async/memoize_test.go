@@ -0,0 +1,119 @@
+package async_test
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	async "github.com/eolme/go-promise/async"
+)
+
+func TestMemoize(t *testing.T) {
+	t.Parallel()
+
+	calls := uint32(0)
+	lookup := async.Memoize(func(key string) (int, error) {
+		atomic.AddUint32(&calls, 1)
+		time.Sleep(10 * time.Millisecond)
+
+		return len(key), nil
+	}, async.MemoizeOptions{})
+
+	first := lookup("wow")
+	second := lookup("wow")
+
+	result, reason := second.Wait()
+
+	assertEqual(t, first, second)
+	assertEqual(t, result, 3)
+	assertEqual(t, reason, nil)
+	assertEqual(t, atomic.LoadUint32(&calls), uint32(1))
+}
+
+func TestMemoizeExpires(t *testing.T) {
+	t.Parallel()
+
+	calls := uint32(0)
+	lookup := async.Memoize(func(key int) (uint32, error) {
+		return atomic.AddUint32(&calls, 1), nil
+	}, async.MemoizeOptions{
+		TTL: time.Millisecond,
+	})
+
+	first, _ := lookup(1).Wait()
+
+	result, _ := lookup(1).Wait()
+	for result == first {
+		time.Sleep(time.Millisecond)
+		result, _ = lookup(1).Wait()
+	}
+
+	assertEqual(t, result, uint32(2))
+}
+
+func TestMemoizeEvictsRejected(t *testing.T) {
+	t.Parallel()
+
+	calls := uint32(0)
+	lookup := async.Memoize(func(key int) (any, error) {
+		if atomic.AddUint32(&calls, 1) == 1 {
+			return nil, errors.New("unavailable")
+		}
+
+		return key, nil
+	}, async.MemoizeOptions{
+		EvictOnReject: true,
+	})
+
+	_, reason := lookup(1).Wait()
+	assertEqual(t, reason != nil, true)
+
+	result, reason := lookup(1).Wait()
+	for reason != nil {
+		result, reason = lookup(1).Wait()
+	}
+
+	assertEqual(t, result, 1)
+	assertEqual(t, atomic.LoadUint32(&calls), uint32(2))
+}
+
+func TestMemoizeKeepsRejected(t *testing.T) {
+	t.Parallel()
+
+	calls := uint32(0)
+	lookup := async.Memoize(func(key int) (any, error) {
+		atomic.AddUint32(&calls, 1)
+
+		return nil, errors.New("unavailable")
+	}, async.MemoizeOptions{})
+
+	first := lookup(1)
+	first.Wait()
+
+	assertEqual(t, lookup(1), first)
+	assertEqual(t, atomic.LoadUint32(&calls), uint32(1))
+}
+
+func TestMemoizeBy(t *testing.T) {
+	t.Parallel()
+
+	calls := uint32(0)
+	lookup := async.MemoizeBy(func(keys []int) (int, error) {
+		atomic.AddUint32(&calls, 1)
+
+		return len(keys), nil
+	}, func(keys []int) string {
+		return fmt.Sprint(keys)
+	}, async.MemoizeOptions{
+		MaxEntries: 1,
+	})
+
+	lookup([]int{1, 2}).Wait()
+	lookup([]int{1, 2}).Wait()
+	lookup([]int{3}).Wait()
+	lookup([]int{1, 2}).Wait()
+
+	assertEqual(t, atomic.LoadUint32(&calls), uint32(3))
+}
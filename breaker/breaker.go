@@ -0,0 +1,257 @@
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	promise "github.com/eolme/go-promise/promise"
+)
+
+var ErrCircuitOpen = errors.New("Circuit open")
+
+const defaultWindow = 10 * time.Second
+
+type (
+	BreakerState       string
+	BreakerStateChange func(from BreakerState, to BreakerState)
+	BreakerOptions     struct {
+		Window              time.Duration
+		MinRequests         int
+		FailureRate         float64
+		ConsecutiveFailures int
+		Cooldown            time.Duration
+		HalfOpenRequests    int
+		OnStateChange       BreakerStateChange
+	}
+	BreakerCounters struct {
+		Requests     uint64
+		Successes    uint64
+		Failures     uint64
+		Rejections   uint64
+		StateChanges uint64
+	}
+)
+
+const (
+	BreakerStateClosed   BreakerState = "closed"
+	BreakerStateOpen     BreakerState = "open"
+	BreakerStateHalfOpen BreakerState = "half-open"
+)
+
+type sample struct {
+	at     time.Time
+	failed bool
+}
+
+type transition struct {
+	from BreakerState
+	to   BreakerState
+}
+
+type Breaker struct {
+	mutex       sync.Mutex
+	options     BreakerOptions
+	state       BreakerState
+	opened      time.Time
+	samples     []sample
+	consecutive int
+	probes      int
+	counters    BreakerCounters
+}
+
+func New(options BreakerOptions) (breaker *Breaker) {
+	if options.Window <= 0 {
+		options.Window = defaultWindow
+	}
+
+	if options.HalfOpenRequests <= 0 {
+		options.HalfOpenRequests = 1
+	}
+
+	breaker = &Breaker{
+		options: options,
+		state:   BreakerStateClosed,
+		samples: []sample{},
+	}
+
+	return breaker
+}
+
+func Wrap(fn func() *promise.Promise, options BreakerOptions) func() *promise.Promise {
+	breaker := New(options)
+
+	return func() *promise.Promise {
+		return breaker.Execute(fn)
+	}
+}
+
+func (self *Breaker) Execute(fn func() *promise.Promise) *promise.Promise {
+	self.mutex.Lock()
+
+	now := time.Now()
+	changes := []transition{}
+
+	if self.state == BreakerStateOpen && !now.Before(self.opened.Add(self.options.Cooldown)) {
+		changes = append(changes, self.transition(BreakerStateHalfOpen, now))
+	}
+
+	if self.state == BreakerStateOpen || (self.state == BreakerStateHalfOpen && self.probes >= self.options.HalfOpenRequests) {
+		self.counters.Rejections++
+		self.mutex.Unlock()
+
+		self.notify(changes)
+
+		return promise.Reject(ErrCircuitOpen)
+	}
+
+	if self.state == BreakerStateHalfOpen {
+		self.probes++
+	}
+
+	self.counters.Requests++
+	state := self.state
+	self.mutex.Unlock()
+
+	self.notify(changes)
+
+	result := promise.Try(func() (any, error) {
+		return fn(), nil
+	})
+
+	return promise.New(func(resolve promise.PromiseResolve, reject promise.PromiseReject) {
+		go func() {
+			unpacked, reason := result.Wait()
+
+			self.record(state, reason != nil)
+
+			if reason != nil {
+				reject(reason)
+			} else {
+				resolve(unpacked)
+			}
+		}()
+	})
+}
+
+func (self *Breaker) State() BreakerState {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return self.state
+}
+
+func (self *Breaker) Counters() BreakerCounters {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return self.counters
+}
+
+func (self *Breaker) record(started BreakerState, failed bool) {
+	self.mutex.Lock()
+
+	now := time.Now()
+	changes := []transition{}
+
+	if failed {
+		self.counters.Failures++
+	} else {
+		self.counters.Successes++
+	}
+
+	switch self.state {
+	case BreakerStateHalfOpen:
+		if started != BreakerStateHalfOpen {
+			break
+		}
+
+		if failed {
+			changes = append(changes, self.transition(BreakerStateOpen, now))
+		} else {
+			changes = append(changes, self.transition(BreakerStateClosed, now))
+		}
+	case BreakerStateClosed:
+		self.samples = append(self.samples, sample{
+			at:     now,
+			failed: failed,
+		})
+		self.prune(now)
+
+		if failed {
+			self.consecutive++
+		} else {
+			self.consecutive = 0
+		}
+
+		if self.tripped() {
+			changes = append(changes, self.transition(BreakerStateOpen, now))
+		}
+	}
+
+	self.mutex.Unlock()
+
+	self.notify(changes)
+}
+
+func (self *Breaker) tripped() bool {
+	if self.options.ConsecutiveFailures > 0 && self.consecutive >= self.options.ConsecutiveFailures {
+		return true
+	}
+
+	if self.options.FailureRate > 0 && len(self.samples) > 0 && len(self.samples) >= self.options.MinRequests {
+		failures := 0
+		for _, current := range self.samples {
+			if current.failed {
+				failures++
+			}
+		}
+
+		return float64(failures)/float64(len(self.samples)) >= self.options.FailureRate
+	}
+
+	return false
+}
+
+func (self *Breaker) prune(now time.Time) {
+	threshold := now.Add(-self.options.Window)
+
+	index := 0
+	for index < len(self.samples) && self.samples[index].at.Before(threshold) {
+		index++
+	}
+
+	self.samples = self.samples[index:]
+}
+
+func (self *Breaker) transition(state BreakerState, now time.Time) (change transition) {
+	change = transition{
+		from: self.state,
+		to:   state,
+	}
+
+	self.state = state
+	self.counters.StateChanges++
+
+	switch state {
+	case BreakerStateOpen:
+		self.opened = now
+	case BreakerStateHalfOpen:
+		self.probes = 0
+	case BreakerStateClosed:
+		self.samples = []sample{}
+		self.consecutive = 0
+	}
+
+	return change
+}
+
+func (self *Breaker) notify(changes []transition) {
+	if self.options.OnStateChange == nil {
+		return
+	}
+
+	for _, change := range changes {
+		self.options.OnStateChange(change.from, change.to)
+	}
+}
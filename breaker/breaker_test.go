@@ -0,0 +1,200 @@
+package breaker_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	breaker "github.com/eolme/go-promise/breaker"
+	promise "github.com/eolme/go-promise/promise"
+)
+
+func assertEqual(t *testing.T, actual any, expected any) {
+	if actual == expected {
+		t.Logf("Assertion success: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	} else {
+		t.Errorf("Assertion fail: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	}
+}
+
+var errUnavailable = errors.New("unavailable")
+
+func succeed() *promise.Promise {
+	return promise.Resolve(nil)
+}
+
+func fail() *promise.Promise {
+	return promise.Reject(errUnavailable)
+}
+
+func TestConsecutiveFailures(t *testing.T) {
+	t.Parallel()
+
+	instance := breaker.New(breaker.BreakerOptions{
+		ConsecutiveFailures: 2,
+		Cooldown:            time.Hour,
+	})
+
+	instance.Execute(fail).Wait()
+	instance.Execute(succeed).Wait()
+	instance.Execute(fail).Wait()
+	assertEqual(t, instance.State(), breaker.BreakerStateClosed)
+
+	instance.Execute(fail).Wait()
+	assertEqual(t, instance.State(), breaker.BreakerStateOpen)
+
+	called := false
+	_, reason := instance.Execute(func() *promise.Promise {
+		called = true
+		return succeed()
+	}).Wait()
+
+	assertEqual(t, reason, breaker.ErrCircuitOpen)
+	assertEqual(t, called, false)
+
+	counters := instance.Counters()
+	assertEqual(t, counters.Requests, uint64(4))
+	assertEqual(t, counters.Failures, uint64(3))
+	assertEqual(t, counters.Successes, uint64(1))
+	assertEqual(t, counters.Rejections, uint64(1))
+}
+
+func TestFailureRate(t *testing.T) {
+	t.Parallel()
+
+	instance := breaker.New(breaker.BreakerOptions{
+		FailureRate: 0.5,
+		MinRequests: 4,
+		Cooldown:    time.Hour,
+	})
+
+	instance.Execute(fail).Wait()
+	instance.Execute(fail).Wait()
+	instance.Execute(fail).Wait()
+	assertEqual(t, instance.State(), breaker.BreakerStateClosed)
+
+	instance.Execute(succeed).Wait()
+	assertEqual(t, instance.State(), breaker.BreakerStateOpen)
+}
+
+func TestSlidingWindow(t *testing.T) {
+	t.Parallel()
+
+	instance := breaker.New(breaker.BreakerOptions{
+		Window:      20 * time.Millisecond,
+		FailureRate: 0.5,
+		MinRequests: 2,
+		Cooldown:    time.Hour,
+	})
+
+	instance.Execute(fail).Wait()
+	time.Sleep(40 * time.Millisecond)
+
+	instance.Execute(succeed).Wait()
+	instance.Execute(succeed).Wait()
+	assertEqual(t, instance.State(), breaker.BreakerStateClosed)
+
+	instance.Execute(fail).Wait()
+	assertEqual(t, instance.State(), breaker.BreakerStateClosed)
+
+	instance.Execute(fail).Wait()
+	assertEqual(t, instance.State(), breaker.BreakerStateOpen)
+}
+
+func TestCooldown(t *testing.T) {
+	t.Parallel()
+
+	var mutex sync.Mutex
+	changes := []breaker.BreakerState{}
+
+	instance := breaker.New(breaker.BreakerOptions{
+		ConsecutiveFailures: 1,
+		Cooldown:            20 * time.Millisecond,
+		OnStateChange: func(from breaker.BreakerState, to breaker.BreakerState) {
+			mutex.Lock()
+			changes = append(changes, to)
+			mutex.Unlock()
+		},
+	})
+
+	instance.Execute(fail).Wait()
+	_, reason := instance.Execute(succeed).Wait()
+	assertEqual(t, reason, breaker.ErrCircuitOpen)
+
+	time.Sleep(40 * time.Millisecond)
+
+	instance.Execute(fail).Wait()
+	assertEqual(t, instance.State(), breaker.BreakerStateOpen)
+
+	time.Sleep(40 * time.Millisecond)
+
+	_, reason = instance.Execute(succeed).Wait()
+	assertEqual(t, reason, nil)
+	assertEqual(t, instance.State(), breaker.BreakerStateClosed)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	expected := []breaker.BreakerState{
+		breaker.BreakerStateOpen,
+		breaker.BreakerStateHalfOpen,
+		breaker.BreakerStateOpen,
+		breaker.BreakerStateHalfOpen,
+		breaker.BreakerStateClosed,
+	}
+
+	assertEqual(t, len(changes), len(expected))
+	for index := range expected {
+		assertEqual(t, changes[index], expected[index])
+	}
+}
+
+func TestHalfOpenLimitsProbes(t *testing.T) {
+	t.Parallel()
+
+	instance := breaker.New(breaker.BreakerOptions{
+		ConsecutiveFailures: 1,
+		Cooldown:            time.Millisecond,
+	})
+
+	instance.Execute(fail).Wait()
+	time.Sleep(10 * time.Millisecond)
+
+	probe, resolve, _ := promise.WithResolvers()
+	first := instance.Execute(func() *promise.Promise {
+		return probe
+	})
+
+	_, reason := instance.Execute(succeed).Wait()
+	assertEqual(t, reason, breaker.ErrCircuitOpen)
+
+	resolve(nil)
+	first.Wait()
+	assertEqual(t, instance.State(), breaker.BreakerStateClosed)
+}
+
+func TestPanickingProbe(t *testing.T) {
+	t.Parallel()
+
+	instance := breaker.New(breaker.BreakerOptions{
+		ConsecutiveFailures: 1,
+		Cooldown:            time.Millisecond,
+	})
+
+	instance.Execute(fail).Wait()
+	time.Sleep(10 * time.Millisecond)
+
+	_, reason := instance.Execute(func() *promise.Promise {
+		panic("something went wrong")
+	}).Wait()
+
+	assertEqual(t, reason != nil, true)
+	assertEqual(t, instance.State(), breaker.BreakerStateOpen)
+
+	time.Sleep(10 * time.Millisecond)
+
+	_, reason = instance.Execute(succeed).Wait()
+	assertEqual(t, reason, nil)
+	assertEqual(t, instance.State(), breaker.BreakerStateClosed)
+}
@@ -0,0 +1,215 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	promise "github.com/eolme/go-promise/promise"
+	singleflight "github.com/eolme/go-promise/singleflight"
+)
+
+type (
+	CacheLoader[K comparable] func(key K) (any, error)
+	CacheOptions              struct {
+		TTL                  time.Duration
+		NegativeTTL          time.Duration
+		StaleWhileRevalidate time.Duration
+		MaxEntries           int
+	}
+)
+
+type entry[K comparable] struct {
+	key        K
+	load       func() (any, error)
+	promise    *promise.Promise
+	settled    bool
+	rejected   bool
+	refreshing bool
+	expires    time.Time
+}
+
+type Cache[K comparable] struct {
+	mutex   sync.Mutex
+	options CacheOptions
+	loader  CacheLoader[K]
+	flight  *singleflight.Group[K]
+	entries map[K]*list.Element
+	order   *list.List
+}
+
+func New[K comparable](loader CacheLoader[K], options CacheOptions) (cache *Cache[K]) {
+	cache = &Cache[K]{
+		options: options,
+		loader:  loader,
+		flight:  singleflight.New[K](),
+		entries: map[K]*list.Element{},
+		order:   list.New(),
+	}
+
+	return cache
+}
+
+func (self *Cache[K]) Get(key K) *promise.Promise {
+	return self.GetOrLoad(key, func() (any, error) {
+		return self.loader(key)
+	})
+}
+
+func (self *Cache[K]) GetOrLoad(key K, load func() (any, error)) *promise.Promise {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	now := time.Now()
+
+	if element, ok := self.entries[key]; ok {
+		current := element.Value.(*entry[K])
+
+		if !current.settled || !self.expired(current, now) {
+			self.order.MoveToFront(element)
+			return current.promise
+		}
+
+		if !current.rejected && self.stale(current, now) {
+			self.order.MoveToFront(element)
+
+			if !current.refreshing {
+				current.refreshing = true
+				self.refresh(current)
+			}
+
+			return current.promise
+		}
+
+		self.remove(element)
+	}
+
+	return self.load(key, load)
+}
+
+func (self *Cache[K]) Set(key K, value any) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if element, ok := self.entries[key]; ok {
+		self.remove(element)
+	}
+
+	current := self.insert(key, promise.Resolve(value), func() (any, error) {
+		return self.loader(key)
+	})
+	self.settle(current, false, time.Now())
+}
+
+func (self *Cache[K]) Delete(key K) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if element, ok := self.entries[key]; ok {
+		self.remove(element)
+	}
+}
+
+func (self *Cache[K]) Len() int {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return self.order.Len()
+}
+
+func (self *Cache[K]) load(key K, load func() (any, error)) *promise.Promise {
+	loaded, _ := self.flight.Do(key, load)
+
+	current := self.insert(key, loaded, load)
+
+	go func() {
+		_, reason := loaded.Wait()
+
+		self.mutex.Lock()
+		defer self.mutex.Unlock()
+
+		element, ok := self.entries[key]
+		if !ok || element.Value != current || current.promise != loaded {
+			return
+		}
+
+		if reason != nil && self.options.NegativeTTL <= 0 {
+			self.remove(element)
+			return
+		}
+
+		self.settle(current, reason != nil, time.Now())
+	}()
+
+	return loaded
+}
+
+func (self *Cache[K]) refresh(current *entry[K]) {
+	refreshed, _ := self.flight.Do(current.key, current.load)
+
+	go func() {
+		_, reason := refreshed.Wait()
+
+		self.mutex.Lock()
+		defer self.mutex.Unlock()
+
+		current.refreshing = false
+
+		element, ok := self.entries[current.key]
+		if !ok || element.Value != current || reason != nil {
+			return
+		}
+
+		current.promise = refreshed
+		self.settle(current, false, time.Now())
+	}()
+}
+
+func (self *Cache[K]) insert(key K, loaded *promise.Promise, load func() (any, error)) (current *entry[K]) {
+	current = &entry[K]{
+		key:     key,
+		load:    load,
+		promise: loaded,
+	}
+
+	self.entries[key] = self.order.PushFront(current)
+
+	if self.options.MaxEntries > 0 {
+		for self.order.Len() > self.options.MaxEntries {
+			self.remove(self.order.Back())
+		}
+	}
+
+	return current
+}
+
+func (self *Cache[K]) remove(element *list.Element) {
+	current := element.Value.(*entry[K])
+
+	self.order.Remove(element)
+	delete(self.entries, current.key)
+}
+
+func (self *Cache[K]) settle(current *entry[K], rejected bool, now time.Time) {
+	current.settled = true
+	current.rejected = rejected
+
+	ttl := self.options.TTL
+	if rejected {
+		ttl = self.options.NegativeTTL
+	}
+
+	if ttl > 0 {
+		current.expires = now.Add(ttl)
+	} else {
+		current.expires = time.Time{}
+	}
+}
+
+func (self *Cache[K]) expired(current *entry[K], now time.Time) bool {
+	return !current.expires.IsZero() && !now.Before(current.expires)
+}
+
+func (self *Cache[K]) stale(current *entry[K], now time.Time) bool {
+	return self.options.StaleWhileRevalidate > 0 && now.Before(current.expires.Add(self.options.StaleWhileRevalidate))
+}
@@ -0,0 +1,138 @@
+package cache_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cache "github.com/eolme/go-promise/cache"
+)
+
+func assertEqual(t *testing.T, actual any, expected any) {
+	if actual == expected {
+		t.Logf("Assertion success: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	} else {
+		t.Errorf("Assertion fail: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	}
+}
+
+func TestShared(t *testing.T) {
+	t.Parallel()
+
+	calls := uint32(0)
+	instance := cache.New(func(key string) (any, error) {
+		atomic.AddUint32(&calls, 1)
+		time.Sleep(50 * time.Millisecond)
+
+		return key, nil
+	}, cache.CacheOptions{})
+
+	first := instance.Get("key")
+	second := instance.Get("key")
+
+	result, _ := first.Wait()
+	assertEqual(t, result, "key")
+
+	result, _ = second.Wait()
+	assertEqual(t, result, "key")
+
+	assertEqual(t, atomic.LoadUint32(&calls), uint32(1))
+}
+
+func TestTTL(t *testing.T) {
+	t.Parallel()
+
+	calls := uint32(0)
+	instance := cache.New(func(key string) (any, error) {
+		return atomic.AddUint32(&calls, 1), nil
+	}, cache.CacheOptions{
+		TTL: 50 * time.Millisecond,
+	})
+
+	result, _ := instance.Get("key").Wait()
+	assertEqual(t, result, uint32(1))
+
+	time.Sleep(10 * time.Millisecond)
+
+	result, _ = instance.Get("key").Wait()
+	assertEqual(t, result, uint32(1))
+
+	time.Sleep(100 * time.Millisecond)
+
+	result, _ = instance.Get("key").Wait()
+	assertEqual(t, result, uint32(2))
+}
+
+func TestNegative(t *testing.T) {
+	t.Parallel()
+
+	calls := uint32(0)
+	failing := func(key string) (any, error) {
+		atomic.AddUint32(&calls, 1)
+
+		return nil, errors.New("failed")
+	}
+
+	instance := cache.New(failing, cache.CacheOptions{})
+
+	instance.Get("key").Wait()
+	time.Sleep(10 * time.Millisecond)
+	instance.Get("key").Wait()
+
+	assertEqual(t, atomic.LoadUint32(&calls), uint32(2))
+
+	atomic.StoreUint32(&calls, 0)
+	instance = cache.New(failing, cache.CacheOptions{
+		NegativeTTL: time.Second,
+	})
+
+	instance.Get("key").Wait()
+	time.Sleep(10 * time.Millisecond)
+	_, reason := instance.Get("key").Wait()
+
+	assertEqual(t, reason.Error(), "failed")
+	assertEqual(t, atomic.LoadUint32(&calls), uint32(1))
+}
+
+func TestStaleWhileRevalidate(t *testing.T) {
+	t.Parallel()
+
+	calls := uint32(0)
+	instance := cache.New(func(key string) (any, error) {
+		return atomic.AddUint32(&calls, 1), nil
+	}, cache.CacheOptions{
+		TTL:                  20 * time.Millisecond,
+		StaleWhileRevalidate: time.Second,
+	})
+
+	result, _ := instance.Get("key").Wait()
+	assertEqual(t, result, uint32(1))
+
+	time.Sleep(50 * time.Millisecond)
+
+	result, _ = instance.Get("key").Wait()
+	assertEqual(t, result, uint32(1))
+
+	time.Sleep(10 * time.Millisecond)
+
+	result, _ = instance.Get("key").Wait()
+	assertEqual(t, result, uint32(2))
+}
+
+func TestMaxEntries(t *testing.T) {
+	t.Parallel()
+
+	instance := cache.New(func(key int) (any, error) {
+		return key, nil
+	}, cache.CacheOptions{
+		MaxEntries: 2,
+	})
+
+	instance.Get(1)
+	instance.Get(2)
+	instance.Get(1)
+	instance.Get(3)
+
+	assertEqual(t, instance.Len(), 2)
+}
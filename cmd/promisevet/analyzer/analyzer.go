@@ -0,0 +1,387 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/cfg"
+)
+
+const (
+	asyncPath    = "github.com/eolme/go-promise/async"
+	deferredPath = "github.com/eolme/go-promise/deferred"
+	promisePath  = "github.com/eolme/go-promise/promise"
+)
+
+const doc = `report misuse of promises
+
+The promisevet analyzer reports:
+  - *promise.Promise results discarded without Then, Catch or Wait;
+  - Wait or async.Await on a promise inside a handler of its own chain;
+  - deferred values that reach a return on some path without Resolve, Reject or escaping;
+  - promise.Promise values copied instead of referenced by pointer.`
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "promisevet",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer, ctrlflow.Analyzer},
+	Run:      run,
+}
+
+var handlers = map[string]bool{
+	"Then":       true,
+	"Catch":      true,
+	"ThenCatch":  true,
+	"Finally":    true,
+	"OnProgress": true,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	graphs := pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs)
+
+	filter := []ast.Node{
+		(*ast.ExprStmt)(nil),
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.FuncDecl)(nil),
+		(*ast.FuncLit)(nil),
+		(*ast.StarExpr)(nil),
+		(*ast.CompositeLit)(nil),
+		(*ast.UnaryExpr)(nil),
+		(*ast.SelectorExpr)(nil),
+	}
+
+	addressed := map[ast.Expr]bool{}
+	selected := map[ast.Expr]bool{}
+
+	inspect.Preorder(filter, func(node ast.Node) {
+		switch node := node.(type) {
+		case *ast.ExprStmt:
+			checkDiscarded(pass, node)
+		case *ast.AssignStmt:
+			if node.Tok == token.DEFINE {
+				names := []*ast.Ident{}
+				for _, lhs := range node.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok {
+						names = append(names, ident)
+					}
+				}
+				checkCopiedNames(pass, names)
+			}
+
+			for index, lhs := range node.Lhs {
+				if index < len(node.Rhs) && len(node.Lhs) == len(node.Rhs) {
+					checkSelfWait(pass, lhs, node.Rhs[index])
+				}
+			}
+		case *ast.ValueSpec:
+			for index, name := range node.Names {
+				if index < len(node.Values) && len(node.Names) == len(node.Values) {
+					checkSelfWait(pass, name, node.Values[index])
+				}
+			}
+			checkCopiedNames(pass, node.Names)
+		case *ast.FuncDecl:
+			checkSignature(pass, node.Type)
+			if node.Body != nil {
+				checkDeferred(pass, node.Body, graphs.FuncDecl(node))
+			}
+		case *ast.FuncLit:
+			checkSignature(pass, node.Type)
+			checkDeferred(pass, node.Body, graphs.FuncLit(node))
+		case *ast.SelectorExpr:
+			selected[ast.Unparen(node.X)] = true
+		case *ast.StarExpr:
+			if !selected[node] && !addressed[node] && isPromise(pass.TypesInfo.TypeOf(node)) {
+				pass.Reportf(node.Pos(), "promise.Promise copied by value; use *promise.Promise")
+			}
+		case *ast.UnaryExpr:
+			if node.Op == token.AND {
+				addressed[ast.Unparen(node.X)] = true
+			}
+		case *ast.CompositeLit:
+			if !addressed[node] && isPromise(pass.TypesInfo.TypeOf(node)) {
+				pass.Reportf(node.Pos(), "promise.Promise created by value; use promise.New")
+			}
+		}
+	})
+
+	return nil, nil
+}
+
+func checkDiscarded(pass *analysis.Pass, statement *ast.ExprStmt) {
+	call, ok := ast.Unparen(statement.X).(*ast.CallExpr)
+	if !ok || !isPromisePointer(pass.TypesInfo.TypeOf(call)) {
+		return
+	}
+
+	if selector, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr); ok && handlers[selector.Sel.Name] {
+		if _, method := pass.TypesInfo.Selections[selector]; method {
+			return
+		}
+	}
+
+	pass.Reportf(call.Pos(), "result of %s is a promise that is never observed; call Then, Catch or Wait", callName(call))
+}
+
+func checkSelfWait(pass *analysis.Pass, lhs ast.Expr, rhs ast.Expr) {
+	ident, ok := lhs.(*ast.Ident)
+	if !ok || ident.Name == "_" {
+		return
+	}
+
+	object := pass.TypesInfo.ObjectOf(ident)
+	if object == nil || !isPromisePointer(object.Type()) {
+		return
+	}
+
+	for current := ast.Unparen(rhs); ; {
+		call, ok := current.(*ast.CallExpr)
+		if !ok {
+			return
+		}
+
+		selector, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+		if !ok {
+			return
+		}
+
+		if handlers[selector.Sel.Name] {
+			for _, arg := range call.Args {
+				if handler, ok := ast.Unparen(arg).(*ast.FuncLit); ok {
+					reportSelfWait(pass, handler, object)
+				}
+			}
+		}
+
+		current = ast.Unparen(selector.X)
+	}
+}
+
+func reportSelfWait(pass *analysis.Pass, handler *ast.FuncLit, object types.Object) {
+	ast.Inspect(handler.Body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		switch fun := ast.Unparen(call.Fun).(type) {
+		case *ast.SelectorExpr:
+			if fun.Sel.Name == "Wait" && refersTo(pass, fun.X, object) {
+				pass.Reportf(call.Pos(), "%s.Wait() inside a handler of its own chain never returns", object.Name())
+				return true
+			}
+
+			if isFunction(pass, fun.Sel, asyncPath, "Await") && len(call.Args) == 1 && refersTo(pass, call.Args[0], object) {
+				pass.Reportf(call.Pos(), "async.Await(%s) inside a handler of its own chain never returns", object.Name())
+			}
+		}
+
+		return true
+	})
+}
+
+func checkDeferred(pass *analysis.Pass, body *ast.BlockStmt, graph *cfg.CFG) {
+	if graph == nil {
+		return
+	}
+
+	created := map[types.Object]*ast.Ident{}
+	creations := map[types.Object]ast.Node{}
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		if _, ok := node.(*ast.FuncLit); ok {
+			return false
+		}
+
+		assign, ok := node.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != len(assign.Rhs) {
+			return true
+		}
+
+		for index, rhs := range assign.Rhs {
+			call, ok := ast.Unparen(rhs).(*ast.CallExpr)
+			if !ok {
+				continue
+			}
+
+			selector, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+			if index, generic := ast.Unparen(call.Fun).(*ast.IndexExpr); generic {
+				selector, ok = ast.Unparen(index.X).(*ast.SelectorExpr)
+			}
+
+			if !ok || !isConstructor(pass, selector.Sel) {
+				continue
+			}
+
+			if ident, ok := assign.Lhs[index].(*ast.Ident); ok && ident.Name != "_" {
+				if object := pass.TypesInfo.ObjectOf(ident); object != nil {
+					created[object] = ident
+					creations[object] = assign
+				}
+			}
+		}
+
+		return true
+	})
+
+	for object, ident := range created {
+		if unsettledPath(pass, graph, object, creations[object]) {
+			pass.Reportf(ident.Pos(), "%s is not resolved or rejected on every path", ident.Name)
+		}
+	}
+}
+
+func unsettledPath(pass *analysis.Pass, graph *cfg.CFG, object types.Object, creation ast.Node) bool {
+	for _, block := range graph.Blocks {
+		for index, node := range block.Nodes {
+			if node == creation {
+				return reachesReturn(pass, block, index+1, object, creation, map[*cfg.Block]bool{})
+			}
+		}
+	}
+
+	return false
+}
+
+func reachesReturn(pass *analysis.Pass, block *cfg.Block, start int, object types.Object, creation ast.Node, visited map[*cfg.Block]bool) bool {
+	for _, node := range block.Nodes[start:] {
+		if node == creation || settles(pass, node, object) {
+			return false
+		}
+	}
+
+	if len(block.Succs) == 0 {
+		if len(block.Nodes) == 0 {
+			return false
+		}
+
+		_, returns := block.Nodes[len(block.Nodes)-1].(*ast.ReturnStmt)
+
+		return returns
+	}
+
+	for _, next := range block.Succs {
+		if visited[next] {
+			continue
+		}
+
+		visited[next] = true
+
+		if reachesReturn(pass, next, 0, object, creation, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func settles(pass *analysis.Pass, root ast.Node, object types.Object) (settled bool) {
+	parents := []ast.Node{}
+
+	ast.Inspect(root, func(node ast.Node) bool {
+		if settled {
+			return false
+		}
+
+		if node == nil {
+			parents = parents[:len(parents)-1]
+			return true
+		}
+
+		if ident, ok := node.(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == object {
+			var selector *ast.SelectorExpr
+			if len(parents) > 0 {
+				selector, _ = parents[len(parents)-1].(*ast.SelectorExpr)
+			}
+
+			if selector == nil || selector.X != ident {
+				settled = true
+			} else if selector.Sel.Name == "Resolve" || selector.Sel.Name == "Reject" {
+				settled = true
+			}
+		}
+
+		parents = append(parents, node)
+
+		return true
+	})
+
+	return settled
+}
+
+func checkSignature(pass *analysis.Pass, signature *ast.FuncType) {
+	fields := []*ast.Field{}
+
+	if signature.Params != nil {
+		fields = append(fields, signature.Params.List...)
+	}
+
+	if signature.Results != nil {
+		fields = append(fields, signature.Results.List...)
+	}
+
+	for _, field := range fields {
+		if isPromise(pass.TypesInfo.TypeOf(field.Type)) {
+			pass.Reportf(field.Type.Pos(), "promise.Promise passed by value; use *promise.Promise")
+		}
+	}
+}
+
+func checkCopiedNames(pass *analysis.Pass, names []*ast.Ident) {
+	for _, name := range names {
+		if object := pass.TypesInfo.Defs[name]; object != nil && isPromise(object.Type()) {
+			pass.Reportf(name.Pos(), "%s holds a promise.Promise by value; use *promise.Promise", name.Name)
+		}
+	}
+}
+
+func isPromise(kind types.Type) bool {
+	named, ok := kind.(*types.Named)
+	if !ok {
+		return false
+	}
+
+	object := named.Obj()
+
+	return object.Pkg() != nil && object.Pkg().Path() == promisePath && object.Name() == "Promise"
+}
+
+func isPromisePointer(kind types.Type) bool {
+	pointer, ok := kind.(*types.Pointer)
+
+	return ok && isPromise(pointer.Elem())
+}
+
+func isConstructor(pass *analysis.Pass, ident *ast.Ident) bool {
+	return isFunction(pass, ident, deferredPath, "New") || isFunction(pass, ident, deferredPath, "NewTyped") || isFunction(pass, ident, deferredPath, "NewStrict")
+}
+
+func isFunction(pass *analysis.Pass, ident *ast.Ident, path string, name string) bool {
+	function, ok := pass.TypesInfo.Uses[ident].(*types.Func)
+
+	return ok && function.Pkg() != nil && function.Pkg().Path() == path && function.Name() == name
+}
+
+func refersTo(pass *analysis.Pass, expr ast.Expr, object types.Object) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+
+	return ok && pass.TypesInfo.Uses[ident] == object
+}
+
+func callName(call *ast.CallExpr) string {
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		return fun.Name
+	case *ast.SelectorExpr:
+		return fun.Sel.Name
+	}
+
+	return "call"
+}
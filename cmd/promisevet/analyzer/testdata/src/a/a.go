@@ -0,0 +1,103 @@
+package a
+
+import (
+	async "github.com/eolme/go-promise/async"
+	deferred "github.com/eolme/go-promise/deferred"
+	promise "github.com/eolme/go-promise/promise"
+)
+
+func discarded() {
+	async.Async(nil) // want `result of Async is a promise that is never observed`
+	promise.Resolve(1).Then(nil)
+	_ = promise.Resolve(1)
+}
+
+func selfWait() {
+	var chained *promise.Promise
+	chained = promise.Resolve(1).Then(func(result any) (any, error) {
+		chained.Wait() // want `chained.Wait\(\) inside a handler of its own chain never returns`
+		return nil, nil
+	})
+
+	var awaited *promise.Promise
+	awaited = promise.Resolve(1).Then(func(result any) (any, error) {
+		return async.Await(awaited) // want `async.Await\(awaited\) inside a handler of its own chain never returns`
+	})
+
+	awaited.Wait()
+}
+
+func unsettled() {
+	forgotten := deferred.New() // want `forgotten is not resolved or rejected on every path`
+	forgotten.Then(nil)
+
+	resolved := deferred.New()
+	resolved.Resolve(1)
+
+	escaped := deferred.New()
+	settle(escaped)
+
+	typed := deferred.NewTyped[int]() // want `typed is not resolved or rejected on every path`
+	typed.Promise().Then(nil)
+
+	settledTyped := deferred.NewTyped[int]()
+	settledTyped.Resolve(1)
+}
+
+func settle(value *deferred.Deferred) {}
+
+func branches(ok bool, reason error) {
+	partial := deferred.New() // want `partial is not resolved or rejected on every path`
+	if ok {
+		partial.Resolve(1)
+	}
+
+	complete := deferred.New()
+	if ok {
+		complete.Resolve(1)
+	} else {
+		complete.Reject(reason)
+	}
+
+	deferredReject := deferred.New()
+	defer deferredReject.Reject(reason)
+
+	panicking := deferred.New()
+	if !ok {
+		panic(reason)
+	}
+	panicking.Resolve(1)
+
+	captured := deferred.New()
+	go func() {
+		captured.Resolve(1)
+	}()
+}
+
+func early(ok bool) *deferred.Deferred {
+	leaked := deferred.New() // want `leaked is not resolved or rejected on every path`
+	if !ok {
+		return nil
+	}
+	leaked.Resolve(1)
+
+	returned := deferred.New()
+
+	return returned
+}
+
+func looped(items []int) {
+	each := deferred.New() // want `each is not resolved or rejected on every path`
+	for range items {
+		each.Resolve(1)
+	}
+}
+
+func copied(value promise.Promise) { // want `promise.Promise passed by value`
+	pointer := promise.Resolve(1)
+	duplicate := *pointer // want `promise.Promise copied by value` `duplicate holds a promise.Promise by value`
+	_ = duplicate
+
+	(*pointer).Then(nil)
+	_ = &*pointer
+}
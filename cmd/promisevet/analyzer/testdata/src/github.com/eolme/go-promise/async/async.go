@@ -0,0 +1,9 @@
+package async
+
+import promise "github.com/eolme/go-promise/promise"
+
+type AsyncFunction func() (any, error)
+
+func Async(fn AsyncFunction) *promise.Promise { return &promise.Promise{} }
+
+func Await(promise *promise.Promise) (any, error) { return promise.Wait() }
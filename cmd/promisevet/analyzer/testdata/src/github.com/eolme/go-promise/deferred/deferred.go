@@ -0,0 +1,21 @@
+package deferred
+
+import promise "github.com/eolme/go-promise/promise"
+
+type Deferred struct {
+	Promise *promise.Promise
+	Resolve promise.PromiseResolve
+	Reject  promise.PromiseReject
+}
+
+func New() *Deferred { return &Deferred{} }
+
+func (self *Deferred) Then(then promise.PromiseThen) *promise.Promise { return self.Promise }
+
+type TypedDeferred[T any] struct{}
+
+func NewTyped[T any]() *TypedDeferred[T] { return &TypedDeferred[T]{} }
+
+func (self *TypedDeferred[T]) Resolve(value T) bool { return true }
+
+func (self *TypedDeferred[T]) Promise() *promise.Promise { return nil }
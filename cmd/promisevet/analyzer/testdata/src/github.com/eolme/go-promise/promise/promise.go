@@ -0,0 +1,26 @@
+package promise
+
+type noCopy struct{}
+
+func (*noCopy) Lock()   {}
+func (*noCopy) Unlock() {}
+
+type Promise struct {
+	noCopy noCopy
+}
+
+type (
+	PromiseResolve func(result any)
+	PromiseReject  func(reason error)
+	PromiseThen    func(result any) (any, error)
+	PromiseCatch   func(reason error) (any, error)
+	PromiseFinally func() error
+)
+
+func New(fn func(resolve PromiseResolve, reject PromiseReject)) *Promise { return &Promise{} }
+func Resolve(result any) *Promise                                        { return &Promise{} }
+
+func (self *Promise) Then(then PromiseThen) *Promise          { return self }
+func (self *Promise) Catch(catch PromiseCatch) *Promise       { return self }
+func (self *Promise) Finally(finally PromiseFinally) *Promise { return self }
+func (self *Promise) Wait() (any, error)                      { return nil, nil }
@@ -0,0 +1,10 @@
+package main
+
+import (
+	analyzer "github.com/eolme/go-promise/cmd/promisevet/analyzer"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(analyzer.Analyzer)
+}
@@ -0,0 +1,132 @@
+package deferred
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime"
+	"sync"
+
+	promise "github.com/eolme/go-promise/promise"
+)
+
+type DeferredMode string
+
+const (
+	DeferredModeLenient DeferredMode = "lenient"
+	DeferredModeLog     DeferredMode = "log"
+	DeferredModePanic   DeferredMode = "panic"
+)
+
+type TypedDeferred[T any] struct {
+	promise *promise.Promise
+	resolve promise.PromiseResolve
+	reject  promise.PromiseReject
+	mode    DeferredMode
+	mutex   sync.Mutex
+	settled bool
+	site    string
+}
+
+func NewTyped[T any]() *TypedDeferred[T] {
+	return NewStrict[T](DeferredModeLenient)
+}
+
+func NewStrict[T any](mode DeferredMode) (deferred *TypedDeferred[T]) {
+	deferred = &TypedDeferred[T]{
+		mode: mode,
+	}
+
+	deferred.promise = promise.New(func(resolve promise.PromiseResolve, reject promise.PromiseReject) {
+		deferred.resolve = resolve
+		deferred.reject = reject
+	})
+
+	return deferred
+}
+
+func (self *TypedDeferred[T]) Promise() *promise.Promise {
+	return self.promise
+}
+
+func (self *TypedDeferred[T]) Resolve(value T) bool {
+	if !self.settle("Resolve") {
+		return false
+	}
+
+	self.resolve(value)
+
+	return true
+}
+
+func (self *TypedDeferred[T]) Reject(reason error) bool {
+	if !self.settle("Reject") {
+		return false
+	}
+
+	self.reject(reason)
+
+	return true
+}
+
+func (self *TypedDeferred[T]) Then(then promise.PromiseThen) *promise.Promise {
+	return self.promise.Then(then)
+}
+
+func (self *TypedDeferred[T]) Catch(catch promise.PromiseCatch) *promise.Promise {
+	return self.promise.Catch(catch)
+}
+
+func (self *TypedDeferred[T]) ThenCatch(then promise.PromiseThen, catch promise.PromiseCatch) *promise.Promise {
+	return self.promise.ThenCatch(then, catch)
+}
+
+func (self *TypedDeferred[T]) Finally(finally promise.PromiseFinally) *promise.Promise {
+	return self.promise.Finally(finally)
+}
+
+func (self *TypedDeferred[T]) Wait() (result T, reason error) {
+	unpacked, reason := self.promise.Wait()
+
+	if typed, ok := unpacked.(T); ok {
+		result = typed
+	}
+
+	return result, reason
+}
+
+func (self *TypedDeferred[T]) settle(method string) bool {
+	self.mutex.Lock()
+
+	if !self.settled {
+		self.settled = true
+
+		if self.mode != DeferredModeLenient {
+			self.site = callerSite()
+		}
+
+		self.mutex.Unlock()
+
+		return true
+	}
+
+	settled := self.site
+	self.mutex.Unlock()
+
+	switch self.mode {
+	case DeferredModeLog:
+		promise.Logger().Warn("Deferred settled more than once", slog.String("method", method), slog.String("site", callerSite()), slog.String("settled", settled))
+	case DeferredModePanic:
+		panic(fmt.Sprintf("Deferred settled more than once: %s at %s, first settled at %s", method, callerSite(), settled))
+	}
+
+	return false
+}
+
+func callerSite() string {
+	_, file, line, ok := runtime.Caller(3)
+	if !ok {
+		return "unknown"
+	}
+
+	return fmt.Sprintf("%s:%d", file, line)
+}
@@ -0,0 +1,112 @@
+package deferred_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	deferred "github.com/eolme/go-promise/deferred"
+	promise "github.com/eolme/go-promise/promise"
+)
+
+func assertEqual(t *testing.T, actual any, expected any) {
+	if actual == expected {
+		t.Logf("Assertion success: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	} else {
+		t.Errorf("Assertion fail: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	}
+}
+
+func TestTypedResolve(t *testing.T) {
+	t.Parallel()
+
+	def := deferred.NewTyped[string]()
+
+	assertEqual(t, def.Resolve("wow"), true)
+	assertEqual(t, def.Resolve("again"), false)
+	assertEqual(t, def.Reject(errors.New("late")), false)
+
+	result, reason := def.Wait()
+
+	assertEqual(t, result, "wow")
+	assertEqual(t, reason, nil)
+}
+
+func TestTypedReject(t *testing.T) {
+	t.Parallel()
+
+	expected := errors.New("unavailable")
+	def := deferred.NewTyped[int]()
+
+	assertEqual(t, def.Reject(expected), true)
+	assertEqual(t, def.Resolve(1), false)
+
+	result, reason := def.Wait()
+
+	assertEqual(t, result, 0)
+	assertEqual(t, reason, expected)
+}
+
+func TestStrictPanic(t *testing.T) {
+	t.Parallel()
+
+	def := deferred.NewStrict[int](deferred.DeferredModePanic)
+	def.Resolve(1)
+
+	defer func() {
+		message := fmt.Sprint(recover())
+
+		assertEqual(t, strings.HasPrefix(message, "Deferred settled more than once: Reject at "), true)
+		assertEqual(t, strings.Count(message, "typed_test.go"), 2)
+	}()
+
+	def.Reject(errors.New("late"))
+}
+
+func TestStrictLog(t *testing.T) {
+	buffer := bytes.Buffer{}
+	promise.WithLogger(slog.New(slog.NewTextHandler(&buffer, nil)))
+	defer promise.WithLogger(nil)
+
+	def := deferred.NewStrict[int](deferred.DeferredModeLog)
+
+	assertEqual(t, def.Resolve(1), true)
+	assertEqual(t, def.Resolve(2), false)
+
+	output := buffer.String()
+
+	assertEqual(t, strings.Contains(output, `msg="Deferred settled more than once"`), true)
+	assertEqual(t, strings.Contains(output, "method=Resolve"), true)
+	assertEqual(t, strings.Count(output, "typed_test.go"), 2)
+}
+
+func TestStrictConcurrent(t *testing.T) {
+	promise.WithLogger(slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+	defer promise.WithLogger(nil)
+
+	def := deferred.NewStrict[int](deferred.DeferredModeLog)
+
+	wins := uint32(0)
+	wait := sync.WaitGroup{}
+
+	for index := 0; index < 8; index++ {
+		wait.Add(1)
+
+		go func(index int) {
+			defer wait.Done()
+
+			if def.Resolve(index) {
+				atomic.AddUint32(&wins, 1)
+			}
+		}(index)
+	}
+
+	wait.Wait()
+
+	assertEqual(t, wins, uint32(1))
+}
@@ -0,0 +1,105 @@
+package durable
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+
+	panics "github.com/eolme/go-promise/panics"
+	promise "github.com/eolme/go-promise/promise"
+)
+
+type Workflow struct {
+	mutex    sync.Mutex
+	store    DurableStore
+	records  map[string]DurableRecord
+	inflight map[string]*promise.Promise
+}
+
+func Open(store DurableStore) (workflow *Workflow, err error) {
+	records, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	workflow = &Workflow{
+		store:    store,
+		records:  make(map[string]DurableRecord, len(records)),
+		inflight: map[string]*promise.Promise{},
+	}
+
+	for _, record := range records {
+		workflow.records[record.Step] = record
+	}
+
+	return workflow, nil
+}
+
+func Step[T any](workflow *Workflow, name string, fn func() (T, error)) *promise.Promise {
+	workflow.mutex.Lock()
+	defer workflow.mutex.Unlock()
+
+	if existing, ok := workflow.inflight[name]; ok {
+		return existing
+	}
+
+	var step *promise.Promise
+
+	if record, ok := workflow.records[name]; ok {
+		step = replay[T](record)
+	} else {
+		step = panics.AsyncPanic(func() (any, error) {
+			result, reason := fn()
+
+			return result, workflow.journal(name, result, reason)
+		})
+	}
+
+	workflow.inflight[name] = step
+
+	return step
+}
+
+func (self *Workflow) journal(name string, result any, reason error) error {
+	record := DurableRecord{
+		Step: name,
+	}
+
+	if reason != nil {
+		record.Status = promise.PromiseStatusRejected
+		record.Reason = reason.Error()
+	} else {
+		value, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+
+		record.Status = promise.PromiseStatusFulfilled
+		record.Value = value
+	}
+
+	if err := self.store.Append(record); err != nil {
+		return err
+	}
+
+	self.mutex.Lock()
+	self.records[name] = record
+	self.mutex.Unlock()
+
+	return reason
+}
+
+func replay[T any](record DurableRecord) *promise.Promise {
+	if record.Status == promise.PromiseStatusRejected {
+		return promise.Reject(errors.New(record.Reason))
+	}
+
+	var result T
+	if len(record.Value) > 0 {
+		if err := json.Unmarshal(record.Value, &result); err != nil {
+			return promise.Reject(err)
+		}
+	}
+
+	return promise.Resolve(result)
+}
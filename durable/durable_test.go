@@ -0,0 +1,114 @@
+package durable_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	durable "github.com/eolme/go-promise/durable"
+)
+
+func assertEqual(t *testing.T, actual any, expected any) {
+	if actual == expected {
+		t.Logf("Assertion success: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	} else {
+		t.Errorf("Assertion fail: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	}
+}
+
+func open(t *testing.T, path string) (*durable.Workflow, *durable.FileStore) {
+	store, err := durable.NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workflow, err := durable.Open(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return workflow, store
+}
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "journal")
+	calls := 0
+
+	fulfilled := func() (int, error) {
+		calls++
+
+		return 42, nil
+	}
+
+	rejected := func() (int, error) {
+		calls++
+
+		return 0, errors.New("failed")
+	}
+
+	workflow, store := open(t, path)
+
+	result, _ := durable.Step(workflow, "fulfilled", fulfilled).Wait()
+	assertEqual(t, result, 42)
+
+	_, reason := durable.Step(workflow, "rejected", rejected).Wait()
+	assertEqual(t, reason.Error(), "failed")
+
+	store.Close()
+
+	workflow, store = open(t, path)
+	defer store.Close()
+
+	result, _ = durable.Step(workflow, "fulfilled", fulfilled).Wait()
+	assertEqual(t, result, 42)
+
+	_, reason = durable.Step(workflow, "rejected", rejected).Wait()
+	assertEqual(t, reason.Error(), "failed")
+
+	assertEqual(t, calls, 2)
+}
+
+func TestTruncatedJournal(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "journal")
+	calls := 0
+
+	step := func() (int, error) {
+		calls++
+
+		return calls, nil
+	}
+
+	workflow, store := open(t, path)
+	durable.Step(workflow, "first", step).Wait()
+	store.Close()
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file.WriteString(`{"step":"second","sta`)
+	file.Close()
+
+	workflow, store = open(t, path)
+
+	result, _ := durable.Step(workflow, "first", step).Wait()
+	assertEqual(t, result, 1)
+
+	result, _ = durable.Step(workflow, "second", step).Wait()
+	assertEqual(t, result, 2)
+
+	store.Close()
+
+	workflow, store = open(t, path)
+	defer store.Close()
+
+	result, _ = durable.Step(workflow, "second", step).Wait()
+	assertEqual(t, result, 2)
+	assertEqual(t, calls, 2)
+}
@@ -0,0 +1,102 @@
+package durable
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sync"
+
+	promise "github.com/eolme/go-promise/promise"
+)
+
+type DurableRecord struct {
+	Step   string                `json:"step"`
+	Status promise.PromiseStatus `json:"status"`
+	Value  json.RawMessage       `json:"value,omitempty"`
+	Reason string                `json:"reason,omitempty"`
+}
+
+type DurableStore interface {
+	Load() ([]DurableRecord, error)
+	Append(record DurableRecord) error
+}
+
+type FileStore struct {
+	mutex sync.Mutex
+	file  *os.File
+}
+
+func NewFileStore(path string) (store *FileStore, err error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	store = &FileStore{
+		file: file,
+	}
+
+	return store, nil
+}
+
+func (self *FileStore) Load() (records []DurableRecord, err error) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if _, err = self.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	records = []DurableRecord{}
+	reader := bufio.NewReader(self.file)
+	offset := int64(0)
+
+	for {
+		line, err := reader.ReadBytes('\n')
+
+		if errors.Is(err, io.EOF) {
+			if len(line) > 0 {
+				return records, self.file.Truncate(offset)
+			}
+
+			return records, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		record := DurableRecord{}
+		if err = json.Unmarshal(line, &record); err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+		offset += int64(len(line))
+	}
+}
+
+func (self *FileStore) Append(record DurableRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if _, err = self.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	return self.file.Sync()
+}
+
+func (self *FileStore) Close() error {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return self.file.Close()
+}
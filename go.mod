@@ -1,3 +1,3 @@
 module github.com/eolme/go-promise
 
-go 1.19
+go 1.21
@@ -0,0 +1,218 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	promise "github.com/eolme/go-promise/promise"
+)
+
+type GraphTask func(results map[string]any) (any, error)
+
+type GraphSkippedError struct {
+	Task     string
+	Upstream string
+}
+
+type GraphError struct {
+	Failed  map[string]error
+	Skipped []string
+	Results map[string]any
+}
+
+type task struct {
+	name         string
+	dependencies []string
+	fn           GraphTask
+}
+
+type Graph struct {
+	mutex sync.Mutex
+	tasks map[string]*task
+	order []string
+}
+
+func New() (graph *Graph) {
+	graph = &Graph{
+		tasks: map[string]*task{},
+		order: []string{},
+	}
+
+	return graph
+}
+
+func (self *Graph) Add(name string, dependencies []string, fn GraphTask) error {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if _, ok := self.tasks[name]; ok {
+		return errors.New(fmt.Sprintf("Task %s is already registered", name))
+	}
+
+	visited := map[string]bool{}
+	for _, dependency := range dependencies {
+		if self.reaches(dependency, name, visited) {
+			return errors.New(fmt.Sprintf("Dependency cycle detected for task %s", name))
+		}
+	}
+
+	self.tasks[name] = &task{
+		name:         name,
+		dependencies: append([]string{}, dependencies...),
+		fn:           fn,
+	}
+	self.order = append(self.order, name)
+
+	return nil
+}
+
+func (self *Graph) Run() *promise.Promise {
+	self.mutex.Lock()
+
+	tasks := make(map[string]*task, len(self.tasks))
+	for name, current := range self.tasks {
+		tasks[name] = current
+	}
+
+	order := append([]string{}, self.order...)
+
+	self.mutex.Unlock()
+
+	for _, name := range order {
+		for _, dependency := range tasks[name].dependencies {
+			if _, ok := tasks[dependency]; !ok {
+				return promise.Reject(errors.New(fmt.Sprintf("Task %s depends on unknown task %s", name, dependency)))
+			}
+		}
+	}
+
+	scheduled := map[string]*promise.Promise{}
+	all := make([]any, len(order))
+
+	for index, name := range order {
+		all[index] = schedule(tasks, scheduled, name)
+	}
+
+	if len(all) == 0 {
+		return promise.Resolve(map[string]any{})
+	}
+
+	return promise.AllSettled(all).Then(func(packed any) (any, error) {
+		settled := packed.([]promise.PromiseSettled)
+
+		results := map[string]any{}
+		failed := map[string]error{}
+		skipped := []string{}
+
+		for index, name := range order {
+			if settled[index].Status == promise.PromiseStatusFulfilled {
+				results[name] = settled[index].Value
+				continue
+			}
+
+			if errors.As(settled[index].Reason, &GraphSkippedError{}) {
+				skipped = append(skipped, name)
+			} else {
+				failed[name] = settled[index].Reason
+			}
+		}
+
+		if len(failed) > 0 || len(skipped) > 0 {
+			return nil, GraphError{
+				Failed:  failed,
+				Skipped: skipped,
+				Results: results,
+			}
+		}
+
+		return results, nil
+	})
+}
+
+func (self *Graph) reaches(from string, target string, visited map[string]bool) bool {
+	if from == target {
+		return true
+	}
+
+	if visited[from] {
+		return false
+	}
+	visited[from] = true
+
+	current, ok := self.tasks[from]
+	if !ok {
+		return false
+	}
+
+	for _, dependency := range current.dependencies {
+		if self.reaches(dependency, target, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func schedule(tasks map[string]*task, scheduled map[string]*promise.Promise, name string) *promise.Promise {
+	if existing, ok := scheduled[name]; ok {
+		return existing
+	}
+
+	current := tasks[name]
+
+	dependencies := make([]any, len(current.dependencies))
+	for index, dependency := range current.dependencies {
+		dependencies[index] = schedule(tasks, scheduled, dependency)
+	}
+
+	var ready *promise.Promise
+	if len(dependencies) == 0 {
+		ready = promise.Resolve([]promise.PromiseSettled{})
+	} else {
+		ready = promise.AllSettled(dependencies)
+	}
+
+	scheduled[name] = ready.Then(func(packed any) (any, error) {
+		settled := packed.([]promise.PromiseSettled)
+		results := make(map[string]any, len(settled))
+
+		for index, dependency := range current.dependencies {
+			if settled[index].Status == promise.PromiseStatusRejected {
+				return nil, GraphSkippedError{
+					Task:     name,
+					Upstream: dependency,
+				}
+			}
+
+			results[dependency] = settled[index].Value
+		}
+
+		return promise.Try(func() (any, error) {
+			return current.fn(results)
+		}), nil
+	})
+
+	return scheduled[name]
+}
+
+func (self GraphSkippedError) Error() string {
+	return fmt.Sprintf("Task %s skipped because %s did not fulfill", self.Task, self.Upstream)
+}
+
+func (self GraphError) Error() string {
+	names := make([]string, 0, len(self.Failed))
+	for name := range self.Failed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	messages := make([]string, 0, len(names))
+	for _, name := range names {
+		messages = append(messages, fmt.Sprintf("%s: %s", name, self.Failed[name]))
+	}
+
+	return fmt.Sprintf("%d tasks failed, %d skipped: %s", len(self.Failed), len(self.Skipped), strings.Join(messages, "; "))
+}
@@ -0,0 +1,126 @@
+package graph_test
+
+import (
+	"errors"
+	"testing"
+
+	graph "github.com/eolme/go-promise/graph"
+	promise "github.com/eolme/go-promise/promise"
+)
+
+func assertEqual(t *testing.T, actual any, expected any) {
+	if actual == expected {
+		t.Logf("Assertion success: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	} else {
+		t.Errorf("Assertion fail: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	instance := graph.New()
+
+	instance.Add("sum", []string{"a", "b"}, func(results map[string]any) (any, error) {
+		return results["a"].(int) + results["b"].(int), nil
+	})
+	instance.Add("a", nil, func(_ map[string]any) (any, error) {
+		return 1, nil
+	})
+	instance.Add("b", []string{"a"}, func(results map[string]any) (any, error) {
+		return results["a"].(int) + 1, nil
+	})
+
+	result, reason := instance.Run().Wait()
+
+	assertEqual(t, reason, nil)
+	assertEqual(t, result.(map[string]any)["sum"], 3)
+}
+
+func TestCycle(t *testing.T) {
+	t.Parallel()
+
+	instance := graph.New()
+	task := func(_ map[string]any) (any, error) {
+		return nil, nil
+	}
+
+	assertEqual(t, instance.Add("a", []string{"b"}, task), nil)
+	assertEqual(t, instance.Add("b", []string{"c"}, task), nil)
+	assertEqual(t, instance.Add("c", []string{"a"}, task) != nil, true)
+	assertEqual(t, instance.Add("d", []string{"d"}, task) != nil, true)
+}
+
+func TestSkip(t *testing.T) {
+	t.Parallel()
+
+	dummyReason := errors.New("failed")
+	instance := graph.New()
+
+	instance.Add("a", nil, func(_ map[string]any) (any, error) {
+		return nil, dummyReason
+	})
+	instance.Add("b", []string{"a"}, func(_ map[string]any) (any, error) {
+		t.Error("skipped task should not run")
+
+		return nil, nil
+	})
+	instance.Add("c", nil, func(_ map[string]any) (any, error) {
+		return "c", nil
+	})
+
+	_, reason := instance.Run().Wait()
+	failure := reason.(graph.GraphError)
+
+	assertEqual(t, failure.Failed["a"], dummyReason)
+	assertEqual(t, failure.Skipped[0], "b")
+	assertEqual(t, failure.Results["c"], "c")
+}
+
+func TestPanic(t *testing.T) {
+	t.Parallel()
+
+	instance := graph.New()
+
+	instance.Add("a", nil, func(_ map[string]any) (any, error) {
+		panic("boom")
+	})
+	instance.Add("b", []string{"a"}, func(_ map[string]any) (any, error) {
+		t.Error("skipped task should not run")
+
+		return nil, nil
+	})
+
+	_, reason := instance.Run().Wait()
+	failure := reason.(graph.GraphError)
+
+	var panicked promise.PromisePanicError
+	assertEqual(t, errors.As(failure.Failed["a"], &panicked), true)
+	assertEqual(t, panicked.Value, "boom")
+	assertEqual(t, failure.Skipped[0], "b")
+}
+
+func TestRunWithLongStackTraces(t *testing.T) {
+	promise.SetLongStackTraces(true)
+	defer promise.SetLongStackTraces(false)
+
+	dummyReason := errors.New("failed")
+
+	instance := graph.New()
+
+	instance.Add("a", nil, func(_ map[string]any) (any, error) {
+		return nil, dummyReason
+	})
+	instance.Add("b", []string{"a"}, func(_ map[string]any) (any, error) {
+		return nil, nil
+	})
+
+	_, reason := instance.Run().Wait()
+
+	var failure graph.GraphError
+	assertEqual(t, errors.As(reason, &failure), true)
+	assertEqual(t, len(failure.Failed), 1)
+	assertEqual(t, errors.Is(failure.Failed["a"], dummyReason), true)
+	assertEqual(t, len(failure.Skipped), 1)
+	assertEqual(t, failure.Skipped[0], "b")
+}
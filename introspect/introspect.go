@@ -0,0 +1,188 @@
+package introspect
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	promise "github.com/eolme/go-promise/promise"
+)
+
+const summaryLength = 64
+
+type Node struct {
+	ID        uint64                `json:"id"`
+	Parents   []uint64              `json:"parents"`
+	Operation string                `json:"operation"`
+	Status    promise.PromiseStatus `json:"status"`
+	Summary   string                `json:"summary,omitempty"`
+	Created   time.Time             `json:"created"`
+	Settled   time.Time             `json:"settled"`
+	Age       time.Duration         `json:"age"`
+}
+
+type Registry struct {
+	mutex   sync.Mutex
+	retain  int
+	nodes   map[uint64]*Node
+	settled []uint64
+}
+
+func New(retain int) (registry *Registry) {
+	registry = &Registry{
+		retain:  retain,
+		nodes:   map[uint64]*Node{},
+		settled: []uint64{},
+	}
+
+	return registry
+}
+
+func Enable(retain int) (registry *Registry) {
+	registry = New(retain)
+	promise.AddHook(registry)
+
+	return registry
+}
+
+func (self *Registry) Disable() {
+	promise.RemoveHook(self)
+}
+
+func (self *Registry) Created(info promise.PromiseInfo) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.nodes[info.ID] = &Node{
+		ID:        info.ID,
+		Parents:   info.Parents,
+		Operation: info.Operation,
+		Status:    info.Status,
+		Created:   info.Created,
+	}
+}
+
+func (self *Registry) Settled(info promise.PromiseInfo) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	node, ok := self.nodes[info.ID]
+	if !ok {
+		return
+	}
+
+	node.Status = info.Status
+	node.Settled = info.Settled
+
+	if info.Reason != nil {
+		node.Summary = summarize(info.Reason.Error())
+	} else {
+		node.Summary = summarize(fmt.Sprintf("%v", info.Value))
+	}
+
+	self.settled = append(self.settled, info.ID)
+
+	if self.retain >= 0 {
+		for len(self.settled) > self.retain {
+			delete(self.nodes, self.settled[0])
+			self.settled = self.settled[1:]
+		}
+	}
+}
+
+func (self *Registry) Nodes() (nodes []Node) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	now := time.Now()
+	nodes = make([]Node, 0, len(self.nodes))
+
+	for _, node := range self.nodes {
+		current := *node
+		current.Age = now.Sub(current.Created)
+		nodes = append(nodes, current)
+	}
+
+	sort.Slice(nodes, func(i int, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+
+	return nodes
+}
+
+func (self *Registry) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(self.Nodes())
+}
+
+func (self *Registry) WriteDOT(w io.Writer) error {
+	nodes := self.Nodes()
+
+	if _, err := io.WriteString(w, "digraph promises {\n"); err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		label := fmt.Sprintf("#%d %s\n%s %s", node.ID, node.Operation, node.Status, node.Age.Round(time.Millisecond))
+		if node.Summary != "" {
+			label += "\n" + node.Summary
+		}
+
+		if _, err := fmt.Fprintf(w, "  p%d [label=%q color=%q];\n", node.ID, label, color(node.Status)); err != nil {
+			return err
+		}
+
+		for _, parent := range node.Parents {
+			if _, err := fmt.Fprintf(w, "  p%d -> p%d;\n", parent, node.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, "}\n")
+
+	return err
+}
+
+func (self *Registry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	switch r.URL.Query().Get("format") {
+	case "dot":
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		err = self.WriteDOT(w)
+	default:
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = self.WriteJSON(w)
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func summarize(value string) string {
+	runes := []rune(value)
+	if len(runes) > summaryLength {
+		return string(runes[:summaryLength]) + "…"
+	}
+
+	return value
+}
+
+func color(status promise.PromiseStatus) string {
+	switch status {
+	case promise.PromiseStatusFulfilled:
+		return "green"
+	case promise.PromiseStatusRejected:
+		return "red"
+	}
+
+	return "gray"
+}
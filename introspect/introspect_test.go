@@ -0,0 +1,173 @@
+package introspect_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	introspect "github.com/eolme/go-promise/introspect"
+	promise "github.com/eolme/go-promise/promise"
+)
+
+func assertEqual(t *testing.T, actual any, expected any) {
+	if actual == expected {
+		t.Logf("Assertion success: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	} else {
+		t.Errorf("Assertion fail: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	}
+}
+
+func create(registry *introspect.Registry, id uint64, parents ...uint64) {
+	registry.Created(promise.PromiseInfo{
+		ID:        id,
+		Parents:   append([]uint64{}, parents...),
+		Operation: "Then",
+		Created:   time.Now(),
+		Status:    promise.PromiseStatusPending,
+	})
+}
+
+func fulfill(registry *introspect.Registry, id uint64, value any) {
+	registry.Settled(promise.PromiseInfo{
+		ID:      id,
+		Settled: time.Now(),
+		Status:  promise.PromiseStatusFulfilled,
+		Value:   value,
+	})
+}
+
+func reject(registry *introspect.Registry, id uint64, reason error) {
+	registry.Settled(promise.PromiseInfo{
+		ID:      id,
+		Settled: time.Now(),
+		Status:  promise.PromiseStatusRejected,
+		Reason:  reason,
+	})
+}
+
+func TestRetention(t *testing.T) {
+	t.Parallel()
+
+	registry := introspect.New(2)
+
+	for id := uint64(1); id <= 4; id++ {
+		create(registry, id)
+	}
+
+	fulfill(registry, 1, "a")
+	fulfill(registry, 2, "b")
+	fulfill(registry, 3, "c")
+
+	nodes := registry.Nodes()
+
+	assertEqual(t, len(nodes), 3)
+	assertEqual(t, nodes[0].ID, uint64(2))
+	assertEqual(t, nodes[1].ID, uint64(3))
+	assertEqual(t, nodes[2].ID, uint64(4))
+	assertEqual(t, nodes[2].Status, promise.PromiseStatusPending)
+}
+
+func TestSummary(t *testing.T) {
+	t.Parallel()
+
+	registry := introspect.New(-1)
+
+	create(registry, 1)
+	create(registry, 2)
+
+	fulfill(registry, 1, strings.Repeat("a", 100))
+	reject(registry, 2, errors.New("unavailable"))
+
+	nodes := registry.Nodes()
+
+	assertEqual(t, nodes[0].Summary, strings.Repeat("a", 64)+"…")
+	assertEqual(t, nodes[1].Summary, "unavailable")
+	assertEqual(t, nodes[1].Status, promise.PromiseStatusRejected)
+}
+
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	registry := introspect.New(-1)
+
+	create(registry, 1)
+	create(registry, 2, 1)
+	fulfill(registry, 1, 42)
+
+	buffer := bytes.Buffer{}
+	assertEqual(t, registry.WriteJSON(&buffer), nil)
+
+	nodes := []introspect.Node{}
+	assertEqual(t, json.Unmarshal(buffer.Bytes(), &nodes), nil)
+
+	assertEqual(t, len(nodes), 2)
+	assertEqual(t, nodes[0].Summary, "42")
+	assertEqual(t, nodes[0].Status, promise.PromiseStatusFulfilled)
+	assertEqual(t, nodes[1].Parents[0], uint64(1))
+	assertEqual(t, nodes[1].Status, promise.PromiseStatusPending)
+}
+
+func TestWriteDOT(t *testing.T) {
+	t.Parallel()
+
+	registry := introspect.New(-1)
+
+	create(registry, 1)
+	create(registry, 2, 1)
+	reject(registry, 1, errors.New("unavailable"))
+
+	buffer := bytes.Buffer{}
+	assertEqual(t, registry.WriteDOT(&buffer), nil)
+
+	output := buffer.String()
+
+	assertEqual(t, strings.HasPrefix(output, "digraph promises {\n"), true)
+	assertEqual(t, strings.HasSuffix(output, "}\n"), true)
+	assertEqual(t, strings.Contains(output, `p1 [label="#1 Then\nrejected`), true)
+	assertEqual(t, strings.Contains(output, `unavailable" color="red"];`), true)
+	assertEqual(t, strings.Contains(output, `color="gray"];`), true)
+	assertEqual(t, strings.Contains(output, "  p1 -> p2;\n"), true)
+}
+
+func TestServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	registry := introspect.New(-1)
+	create(registry, 1)
+
+	recorder := httptest.NewRecorder()
+	registry.ServeHTTP(recorder, httptest.NewRequest("GET", "/debug/promises", nil))
+
+	assertEqual(t, recorder.Header().Get("Content-Type"), "application/json; charset=utf-8")
+	assertEqual(t, strings.Contains(recorder.Body.String(), `"id": 1`), true)
+
+	recorder = httptest.NewRecorder()
+	registry.ServeHTTP(recorder, httptest.NewRequest("GET", "/debug/promises?format=dot", nil))
+
+	assertEqual(t, recorder.Header().Get("Content-Type"), "text/vnd.graphviz; charset=utf-8")
+	assertEqual(t, strings.HasPrefix(recorder.Body.String(), "digraph promises {"), true)
+}
+
+func TestEnable(t *testing.T) {
+	registry := introspect.Enable(-1)
+
+	root := promise.Resolve(1)
+	root.Then(func(result any) (any, error) {
+		return result, nil
+	}).Wait()
+
+	registry.Disable()
+	promise.Resolve(2).Wait()
+
+	nodes := registry.Nodes()
+
+	assertEqual(t, len(nodes), 2)
+	assertEqual(t, nodes[0].Operation, "Resolve")
+	assertEqual(t, nodes[1].Operation, "Then")
+	assertEqual(t, nodes[1].Parents[0], nodes[0].ID)
+	assertEqual(t, nodes[1].Status, promise.PromiseStatusFulfilled)
+}
@@ -0,0 +1,164 @@
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	promise "github.com/eolme/go-promise/promise"
+)
+
+type (
+	LoaderBatch[K comparable, V any] func(keys []K) ([]V, []error)
+	LoaderOptions                    struct {
+		Wait         time.Duration
+		MaxBatchSize int
+		Cache        bool
+	}
+)
+
+type request[K comparable] struct {
+	key     K
+	resolve promise.PromiseResolve
+	reject  promise.PromiseReject
+}
+
+type batch[K comparable] struct {
+	requests []request[K]
+	timer    *time.Timer
+}
+
+type Loader[K comparable, V any] struct {
+	mutex   sync.Mutex
+	fn      LoaderBatch[K, V]
+	options LoaderOptions
+	current *batch[K]
+	cache   map[K]*promise.Promise
+}
+
+func New[K comparable, V any](fn LoaderBatch[K, V], options LoaderOptions) (loader *Loader[K, V]) {
+	loader = &Loader[K, V]{
+		fn:      fn,
+		options: options,
+		current: nil,
+		cache:   map[K]*promise.Promise{},
+	}
+
+	return loader
+}
+
+func (self *Loader[K, V]) Load(key K) (loaded *promise.Promise) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.options.Cache {
+		if cached, ok := self.cache[key]; ok {
+			return cached
+		}
+	}
+
+	loaded = promise.New(func(resolve promise.PromiseResolve, reject promise.PromiseReject) {
+		self.enqueue(request[K]{
+			key:     key,
+			resolve: resolve,
+			reject:  reject,
+		})
+	})
+
+	if self.options.Cache {
+		self.cache[key] = loaded
+	}
+
+	return loaded
+}
+
+func (self *Loader[K, V]) LoadMany(keys []K) *promise.Promise {
+	if len(keys) == 0 {
+		return promise.Resolve([]any{})
+	}
+
+	loaded := make([]any, len(keys))
+
+	for index, key := range keys {
+		loaded[index] = self.Load(key)
+	}
+
+	return promise.All(loaded)
+}
+
+func (self *Loader[K, V]) Prime(key K, value V) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if _, ok := self.cache[key]; !ok && self.options.Cache {
+		self.cache[key] = promise.Resolve(value)
+	}
+}
+
+func (self *Loader[K, V]) Clear(key K) {
+	self.mutex.Lock()
+	delete(self.cache, key)
+	self.mutex.Unlock()
+}
+
+func (self *Loader[K, V]) ClearAll() {
+	self.mutex.Lock()
+	self.cache = map[K]*promise.Promise{}
+	self.mutex.Unlock()
+}
+
+func (self *Loader[K, V]) enqueue(pending request[K]) {
+	if self.current == nil {
+		current := &batch[K]{}
+		current.timer = time.AfterFunc(self.options.Wait, func() {
+			self.mutex.Lock()
+			if self.current == current {
+				self.current = nil
+			}
+			self.mutex.Unlock()
+
+			self.dispatch(current)
+		})
+
+		self.current = current
+	}
+
+	current := self.current
+	current.requests = append(current.requests, pending)
+
+	if self.options.MaxBatchSize > 0 && len(current.requests) >= self.options.MaxBatchSize {
+		self.current = nil
+
+		if current.timer.Stop() {
+			go self.dispatch(current)
+		}
+	}
+}
+
+func (self *Loader[K, V]) dispatch(current *batch[K]) {
+	keys := make([]K, len(current.requests))
+	for index, pending := range current.requests {
+		keys[index] = pending.key
+	}
+
+	values, reasons := self.fn(keys)
+
+	if len(values) != len(keys) {
+		reason := errors.New(fmt.Sprintf("Batch function returned %d values for %d keys", len(values), len(keys)))
+
+		for _, pending := range current.requests {
+			pending.reject(reason)
+		}
+
+		return
+	}
+
+	for index, pending := range current.requests {
+		if index < len(reasons) && reasons[index] != nil {
+			pending.reject(reasons[index])
+		} else {
+			pending.resolve(values[index])
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package loader_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	loader "github.com/eolme/go-promise/loader"
+)
+
+func assertEqual(t *testing.T, actual any, expected any) {
+	if actual == expected {
+		t.Logf("Assertion success: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	} else {
+		t.Errorf("Assertion fail: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+
+	mutex := sync.Mutex{}
+	batches := [][]int{}
+
+	instance := loader.New(func(keys []int) ([]int, []error) {
+		mutex.Lock()
+		batches = append(batches, keys)
+		mutex.Unlock()
+
+		values := make([]int, len(keys))
+		reasons := make([]error, len(keys))
+
+		for index, key := range keys {
+			if key < 0 {
+				reasons[index] = errors.New("negative")
+			} else {
+				values[index] = key * 2
+			}
+		}
+
+		return values, reasons
+	}, loader.LoaderOptions{
+		Wait:         10 * time.Millisecond,
+		MaxBatchSize: 3,
+	})
+
+	first := instance.Load(1)
+	second := instance.Load(-1)
+	third := instance.LoadMany([]int{2, 3})
+
+	result, _ := first.Wait()
+	assertEqual(t, result, 2)
+
+	_, reason := second.Wait()
+	assertEqual(t, reason.Error(), "negative")
+
+	result, _ = third.Wait()
+	assertEqual(t, result.([]any)[1], 6)
+
+	mutex.Lock()
+	assertEqual(t, len(batches), 2)
+	mutex.Unlock()
+}
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	instance := loader.New(func(keys []string) ([]string, []error) {
+		calls++
+
+		return keys, nil
+	}, loader.LoaderOptions{
+		Cache: true,
+	})
+
+	instance.Prime("primed", "value")
+
+	assertEqual(t, instance.Load("key"), instance.Load("key"))
+
+	result, _ := instance.Load("primed").Wait()
+	assertEqual(t, result, "value")
+
+	instance.Load("key").Wait()
+	instance.Clear("key")
+	instance.Load("key").Wait()
+
+	assertEqual(t, calls, 2)
+}
+
+func TestLoadManyEmpty(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	instance := loader.New(func(keys []int) ([]int, []error) {
+		calls++
+
+		return keys, nil
+	}, loader.LoaderOptions{})
+
+	result, reason := instance.LoadMany([]int{}).Wait()
+
+	assertEqual(t, reason, nil)
+	assertEqual(t, len(result.([]any)), 0)
+	assertEqual(t, calls, 0)
+}
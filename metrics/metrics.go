@@ -0,0 +1,175 @@
+package metrics
+
+import (
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	promise "github.com/eolme/go-promise/promise"
+)
+
+var DefaultBuckets = []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10}
+
+type HistogramSnapshot struct {
+	Buckets []float64
+	Counts  []uint64
+	Sum     float64
+	Count   uint64
+}
+
+type MetricsSnapshot struct {
+	Created   uint64
+	Fulfilled uint64
+	Rejected  uint64
+	Panicked  uint64
+	Pending   int64
+	Latency   HistogramSnapshot
+}
+
+type Metrics struct {
+	created   uint64
+	fulfilled uint64
+	rejected  uint64
+	panicked  uint64
+	pending   int64
+	buckets   []float64
+	counts    []uint64
+	sum       uint64
+	count     uint64
+}
+
+func New(buckets []float64) (metrics *Metrics) {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+
+	metrics = &Metrics{
+		buckets: append([]float64{}, buckets...),
+		counts:  make([]uint64, len(buckets)),
+	}
+
+	return metrics
+}
+
+func Enable(buckets []float64) (metrics *Metrics) {
+	metrics = New(buckets)
+	promise.AddHook(metrics)
+
+	return metrics
+}
+
+func (self *Metrics) Disable() {
+	promise.RemoveHook(self)
+}
+
+func (self *Metrics) Created(_ promise.PromiseInfo) {
+	atomic.AddUint64(&self.created, 1)
+	atomic.AddInt64(&self.pending, 1)
+}
+
+func (self *Metrics) Settled(info promise.PromiseInfo) {
+	atomic.AddInt64(&self.pending, -1)
+
+	if info.Status == promise.PromiseStatusFulfilled {
+		atomic.AddUint64(&self.fulfilled, 1)
+	} else {
+		atomic.AddUint64(&self.rejected, 1)
+
+		if info.Panicked {
+			atomic.AddUint64(&self.panicked, 1)
+		}
+	}
+
+	latency := info.Settled.Sub(info.Created)
+	seconds := latency.Seconds()
+
+	for index, bound := range self.buckets {
+		if seconds <= bound {
+			atomic.AddUint64(&self.counts[index], 1)
+			break
+		}
+	}
+
+	atomic.AddUint64(&self.sum, uint64(latency))
+	atomic.AddUint64(&self.count, 1)
+}
+
+func (self *Metrics) Snapshot() (snapshot MetricsSnapshot) {
+	snapshot = MetricsSnapshot{
+		Created:   atomic.LoadUint64(&self.created),
+		Fulfilled: atomic.LoadUint64(&self.fulfilled),
+		Rejected:  atomic.LoadUint64(&self.rejected),
+		Panicked:  atomic.LoadUint64(&self.panicked),
+		Pending:   atomic.LoadInt64(&self.pending),
+		Latency: HistogramSnapshot{
+			Buckets: append([]float64{}, self.buckets...),
+			Counts:  make([]uint64, len(self.buckets)),
+			Sum:     time.Duration(atomic.LoadUint64(&self.sum)).Seconds(),
+			Count:   atomic.LoadUint64(&self.count),
+		},
+	}
+
+	cumulative := uint64(0)
+	for index := range self.counts {
+		cumulative += atomic.LoadUint64(&self.counts[index])
+		snapshot.Latency.Counts[index] = cumulative
+	}
+
+	return snapshot
+}
+
+func (self *Metrics) Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() any {
+		return self.Snapshot()
+	}))
+}
+
+func (self *Metrics) WritePrometheus(w io.Writer) error {
+	snapshot := self.Snapshot()
+
+	counters := []struct {
+		name  string
+		help  string
+		value uint64
+	}{
+		{"promise_created_total", "Promises created.", snapshot.Created},
+		{"promise_fulfilled_total", "Promises fulfilled.", snapshot.Fulfilled},
+		{"promise_rejected_total", "Promises rejected.", snapshot.Rejected},
+		{"promise_panicked_total", "Promises rejected by a recovered panic.", snapshot.Panicked},
+	}
+
+	for _, counter := range counters {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n%s %d\n", counter.name, counter.help, counter.name, counter.name, counter.value); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintf(w, "# HELP promise_pending Promises not settled yet.\n# TYPE promise_pending gauge\npromise_pending %d\n", snapshot.Pending); err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, "# HELP promise_settle_duration_seconds Time from creation to settlement.\n# TYPE promise_settle_duration_seconds histogram\n"); err != nil {
+		return err
+	}
+
+	for index, bound := range snapshot.Latency.Buckets {
+		if _, err := fmt.Fprintf(w, "promise_settle_duration_seconds_bucket{le=\"%g\"} %d\n", bound, snapshot.Latency.Counts[index]); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "promise_settle_duration_seconds_bucket{le=\"+Inf\"} %d\npromise_settle_duration_seconds_sum %g\npromise_settle_duration_seconds_count %d\n", snapshot.Latency.Count, snapshot.Latency.Sum, snapshot.Latency.Count)
+
+	return err
+}
+
+func (self *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	if err := self.WritePrometheus(w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
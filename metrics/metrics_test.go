@@ -0,0 +1,137 @@
+package metrics_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	metrics "github.com/eolme/go-promise/metrics"
+	panics "github.com/eolme/go-promise/panics"
+	promise "github.com/eolme/go-promise/promise"
+)
+
+func assertEqual(t *testing.T, actual any, expected any) {
+	if actual == expected {
+		t.Logf("Assertion success: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	} else {
+		t.Errorf("Assertion fail: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	}
+}
+
+func settle(collected *metrics.Metrics, latency time.Duration, status promise.PromiseStatus, panicked bool) {
+	created := time.Unix(0, 0)
+
+	collected.Created(promise.PromiseInfo{
+		Created: created,
+		Status:  promise.PromiseStatusPending,
+	})
+
+	collected.Settled(promise.PromiseInfo{
+		Created:  created,
+		Settled:  created.Add(latency),
+		Status:   status,
+		Panicked: panicked,
+	})
+}
+
+func TestCounters(t *testing.T) {
+	t.Parallel()
+
+	collected := metrics.New(nil)
+
+	collected.Created(promise.PromiseInfo{
+		Status: promise.PromiseStatusPending,
+	})
+
+	settle(collected, time.Millisecond, promise.PromiseStatusFulfilled, false)
+	settle(collected, time.Millisecond, promise.PromiseStatusRejected, false)
+	settle(collected, time.Millisecond, promise.PromiseStatusRejected, true)
+
+	snapshot := collected.Snapshot()
+
+	assertEqual(t, snapshot.Created, uint64(4))
+	assertEqual(t, snapshot.Fulfilled, uint64(1))
+	assertEqual(t, snapshot.Rejected, uint64(2))
+	assertEqual(t, snapshot.Panicked, uint64(1))
+	assertEqual(t, snapshot.Pending, int64(1))
+}
+
+func TestHistogram(t *testing.T) {
+	t.Parallel()
+
+	collected := metrics.New([]float64{0.001, 0.01, 0.1})
+
+	settle(collected, 500*time.Microsecond, promise.PromiseStatusFulfilled, false)
+	settle(collected, time.Millisecond, promise.PromiseStatusFulfilled, false)
+	settle(collected, 5*time.Millisecond, promise.PromiseStatusFulfilled, false)
+	settle(collected, 50*time.Millisecond, promise.PromiseStatusFulfilled, false)
+	settle(collected, time.Second, promise.PromiseStatusFulfilled, false)
+
+	latency := collected.Snapshot().Latency
+
+	assertEqual(t, latency.Counts[0], uint64(2))
+	assertEqual(t, latency.Counts[1], uint64(3))
+	assertEqual(t, latency.Counts[2], uint64(4))
+	assertEqual(t, latency.Count, uint64(5))
+	assertEqual(t, latency.Sum, 1.0565)
+}
+
+func TestWritePrometheus(t *testing.T) {
+	t.Parallel()
+
+	collected := metrics.New([]float64{0.01, 1})
+
+	settle(collected, 5*time.Millisecond, promise.PromiseStatusFulfilled, false)
+	settle(collected, 2*time.Second, promise.PromiseStatusRejected, true)
+
+	buffer := bytes.Buffer{}
+	assertEqual(t, collected.WritePrometheus(&buffer), nil)
+
+	expected := `# HELP promise_created_total Promises created.
+# TYPE promise_created_total counter
+promise_created_total 2
+# HELP promise_fulfilled_total Promises fulfilled.
+# TYPE promise_fulfilled_total counter
+promise_fulfilled_total 1
+# HELP promise_rejected_total Promises rejected.
+# TYPE promise_rejected_total counter
+promise_rejected_total 1
+# HELP promise_panicked_total Promises rejected by a recovered panic.
+# TYPE promise_panicked_total counter
+promise_panicked_total 1
+# HELP promise_pending Promises not settled yet.
+# TYPE promise_pending gauge
+promise_pending 0
+# HELP promise_settle_duration_seconds Time from creation to settlement.
+# TYPE promise_settle_duration_seconds histogram
+promise_settle_duration_seconds_bucket{le="0.01"} 1
+promise_settle_duration_seconds_bucket{le="1"} 1
+promise_settle_duration_seconds_bucket{le="+Inf"} 2
+promise_settle_duration_seconds_sum 2.005
+promise_settle_duration_seconds_count 2
+`
+
+	assertEqual(t, buffer.String(), expected)
+}
+
+func TestEnable(t *testing.T) {
+	collected := metrics.Enable(nil)
+
+	expected := errors.New("something went wrong")
+
+	_, reason := panics.PromisifyPanic(func() any {
+		panic(expected)
+	}).Wait()
+
+	promise.Reject(expected).Wait()
+	collected.Disable()
+
+	snapshot := collected.Snapshot()
+
+	assertEqual(t, reason, expected)
+	assertEqual(t, snapshot.Created, uint64(2))
+	assertEqual(t, snapshot.Rejected, uint64(2))
+	assertEqual(t, snapshot.Panicked, uint64(1))
+	assertEqual(t, snapshot.Pending, int64(0))
+}
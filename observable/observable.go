@@ -0,0 +1,252 @@
+package observable
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	promise "github.com/eolme/go-promise/promise"
+)
+
+var ErrEmpty = errors.New("No elements in sequence")
+
+type (
+	ObservableNext      func(value any)
+	ObservableError     func(reason error)
+	ObservableComplete  func()
+	ObservableTeardown  func()
+	ObservableSubscribe func(observer *Observer) ObservableTeardown
+)
+
+type Observer struct {
+	next     ObservableNext
+	fail     ObservableError
+	complete ObservableComplete
+	closed   uint32
+	mutex    sync.Mutex
+	teardown ObservableTeardown
+	finished bool
+}
+
+type Subscription struct {
+	observer *Observer
+}
+
+type Observable struct {
+	subscribe ObservableSubscribe
+}
+
+func New(subscribe ObservableSubscribe) (observable *Observable) {
+	observable = &Observable{
+		subscribe: subscribe,
+	}
+
+	return observable
+}
+
+func Of(values ...any) *Observable {
+	return New(func(observer *Observer) ObservableTeardown {
+		for _, value := range values {
+			observer.Next(value)
+		}
+
+		observer.Complete()
+
+		return nil
+	})
+}
+
+func FromPromise(source *promise.Promise) *Observable {
+	return New(func(observer *Observer) ObservableTeardown {
+		go func() {
+			unpacked, reason := source.Wait()
+
+			if reason != nil {
+				observer.Error(reason)
+			} else {
+				observer.Next(unpacked)
+				observer.Complete()
+			}
+		}()
+
+		return nil
+	})
+}
+
+func FirstValueFrom(source *Observable) *promise.Promise {
+	return promise.New(func(resolve promise.PromiseResolve, reject promise.PromiseReject) {
+		source.Take(1).Subscribe(func(value any) {
+			resolve(value)
+		}, func(reason error) {
+			reject(reason)
+		}, func() {
+			reject(ErrEmpty)
+		})
+	})
+}
+
+func (self *Observable) Subscribe(next ObservableNext, fail ObservableError, complete ObservableComplete) *Subscription {
+	observer := &Observer{
+		next:     next,
+		fail:     fail,
+		complete: complete,
+	}
+
+	observer.setTeardown(self.subscribe(observer))
+
+	return &Subscription{
+		observer: observer,
+	}
+}
+
+func (self *Subscription) Unsubscribe() {
+	atomic.StoreUint32(&self.observer.closed, 1)
+	self.observer.runTeardown()
+}
+
+func (self *Subscription) Closed() bool {
+	return self.observer.Closed()
+}
+
+func (self *Observer) Next(value any) {
+	if atomic.LoadUint32(&self.closed) == 0 && self.next != nil {
+		self.next(value)
+	}
+}
+
+func (self *Observer) Error(reason error) {
+	if atomic.CompareAndSwapUint32(&self.closed, 0, 1) {
+		if self.fail != nil {
+			self.fail(reason)
+		}
+
+		self.runTeardown()
+	}
+}
+
+func (self *Observer) Complete() {
+	if atomic.CompareAndSwapUint32(&self.closed, 0, 1) {
+		if self.complete != nil {
+			self.complete()
+		}
+
+		self.runTeardown()
+	}
+}
+
+func (self *Observer) Closed() bool {
+	return atomic.LoadUint32(&self.closed) == 1
+}
+
+func (self *Observer) setTeardown(teardown ObservableTeardown) {
+	self.mutex.Lock()
+
+	if self.finished || atomic.LoadUint32(&self.closed) == 1 {
+		self.finished = true
+		self.mutex.Unlock()
+
+		if teardown != nil {
+			teardown()
+		}
+
+		return
+	}
+
+	self.teardown = teardown
+	self.mutex.Unlock()
+}
+
+func (self *Observer) runTeardown() {
+	self.mutex.Lock()
+
+	teardown := self.teardown
+	self.teardown = nil
+	self.finished = true
+
+	self.mutex.Unlock()
+
+	if teardown != nil {
+		teardown()
+	}
+}
+
+type Subject struct {
+	*Observable
+	mutex     sync.Mutex
+	observers map[*Observer]struct{}
+	closed    bool
+	reason    error
+}
+
+func NewSubject() (subject *Subject) {
+	subject = &Subject{
+		observers: map[*Observer]struct{}{},
+	}
+
+	subject.Observable = New(func(observer *Observer) ObservableTeardown {
+		subject.mutex.Lock()
+
+		if subject.closed {
+			reason := subject.reason
+			subject.mutex.Unlock()
+
+			if reason != nil {
+				observer.Error(reason)
+			} else {
+				observer.Complete()
+			}
+
+			return nil
+		}
+
+		subject.observers[observer] = struct{}{}
+		subject.mutex.Unlock()
+
+		return func() {
+			subject.mutex.Lock()
+			delete(subject.observers, observer)
+			subject.mutex.Unlock()
+		}
+	})
+
+	return subject
+}
+
+func (self *Subject) Next(value any) {
+	for _, observer := range self.snapshot(false, nil) {
+		observer.Next(value)
+	}
+}
+
+func (self *Subject) Error(reason error) {
+	for _, observer := range self.snapshot(true, reason) {
+		observer.Error(reason)
+	}
+}
+
+func (self *Subject) Complete() {
+	for _, observer := range self.snapshot(true, nil) {
+		observer.Complete()
+	}
+}
+
+func (self *Subject) snapshot(close bool, reason error) (observers []*Observer) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.closed {
+		return nil
+	}
+
+	observers = make([]*Observer, 0, len(self.observers))
+	for observer := range self.observers {
+		observers = append(observers, observer)
+	}
+
+	if close {
+		self.closed = true
+		self.reason = reason
+	}
+
+	return observers
+}
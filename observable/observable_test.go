@@ -0,0 +1,243 @@
+package observable_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	observable "github.com/eolme/go-promise/observable"
+	promise "github.com/eolme/go-promise/promise"
+)
+
+func assertEqual(t *testing.T, actual any, expected any) {
+	if actual == expected {
+		t.Logf("Assertion success: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	} else {
+		t.Errorf("Assertion fail: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	}
+}
+
+func collect(source *observable.Observable) *promise.Promise {
+	return promise.New(func(resolve promise.PromiseResolve, reject promise.PromiseReject) {
+		mutex := sync.Mutex{}
+		values := []any{}
+
+		source.Subscribe(func(value any) {
+			mutex.Lock()
+			values = append(values, value)
+			mutex.Unlock()
+		}, func(reason error) {
+			reject(reason)
+		}, func() {
+			mutex.Lock()
+			resolve(values)
+			mutex.Unlock()
+		})
+	})
+}
+
+func TestOperators(t *testing.T) {
+	t.Parallel()
+
+	source := observable.Of(1, 2, 3, 4, 5).
+		Filter(func(value any) bool {
+			return value.(int)%2 == 1
+		}).
+		Map(func(value any) any {
+			return value.(int) * 10
+		}).
+		Take(2)
+
+	result, _ := collect(source).Wait()
+	values := result.([]any)
+
+	assertEqual(t, len(values), 2)
+	assertEqual(t, values[0], 10)
+	assertEqual(t, values[1], 30)
+
+	result, _ = collect(observable.Concat(observable.Of(1), observable.Of(2, 3))).Wait()
+	assertEqual(t, len(result.([]any)), 3)
+
+	result, _ = collect(observable.Merge(observable.Of(1), observable.Of(2, 3))).Wait()
+	assertEqual(t, len(result.([]any)), 3)
+}
+
+func TestSubject(t *testing.T) {
+	t.Parallel()
+
+	subject := observable.NewSubject()
+	debounced := collect(subject.Debounce(20 * time.Millisecond))
+	first := observable.FirstValueFrom(subject.Observable)
+
+	subject.Next(1)
+	subject.Next(2)
+	time.Sleep(50 * time.Millisecond)
+	subject.Next(3)
+	subject.Complete()
+
+	result, _ := first.Wait()
+	assertEqual(t, result, 1)
+
+	result, _ = debounced.Wait()
+	values := result.([]any)
+
+	assertEqual(t, len(values), 2)
+	assertEqual(t, values[0], 2)
+	assertEqual(t, values[1], 3)
+
+	_, reason := observable.FirstValueFrom(subject.Observable).Wait()
+	assertEqual(t, reason, observable.ErrEmpty)
+}
+
+func TestFromPromise(t *testing.T) {
+	t.Parallel()
+
+	dummyReason := errors.New("failed")
+
+	result, _ := observable.FirstValueFrom(observable.FromPromise(promise.Resolve(1))).Wait()
+	assertEqual(t, result, 1)
+
+	_, reason := observable.FirstValueFrom(observable.FromPromise(promise.Reject(dummyReason))).Wait()
+	assertEqual(t, reason, dummyReason)
+}
+
+func TestSwitchMap(t *testing.T) {
+	t.Parallel()
+
+	outer := observable.NewSubject()
+	first := observable.NewSubject()
+	second := observable.NewSubject()
+	inners := map[any]*observable.Subject{"first": first, "second": second}
+
+	torndown := 0
+	switched := collect(outer.SwitchMap(func(value any) *observable.Observable {
+		inner := inners[value]
+
+		return observable.New(func(observer *observable.Observer) observable.ObservableTeardown {
+			subscription := inner.Subscribe(observer.Next, observer.Error, observer.Complete)
+
+			return func() {
+				torndown++
+				subscription.Unsubscribe()
+			}
+		})
+	}))
+
+	outer.Next("first")
+	first.Next(1)
+
+	outer.Next("second")
+	assertEqual(t, torndown, 1)
+
+	first.Next(2)
+	second.Next(3)
+
+	outer.Complete()
+	first.Complete()
+	second.Complete()
+
+	result, reason := switched.Wait()
+	values := result.([]any)
+
+	assertEqual(t, reason, nil)
+	assertEqual(t, len(values), 2)
+	assertEqual(t, values[0], 1)
+	assertEqual(t, values[1], 3)
+}
+
+func TestThrottle(t *testing.T) {
+	t.Parallel()
+
+	subject := observable.NewSubject()
+	throttled := collect(subject.Throttle(50 * time.Millisecond))
+
+	subject.Next(1)
+	subject.Next(2)
+	subject.Next(3)
+	time.Sleep(60 * time.Millisecond)
+	subject.Next(4)
+	subject.Next(5)
+	subject.Complete()
+
+	result, _ := throttled.Wait()
+	values := result.([]any)
+
+	assertEqual(t, len(values), 2)
+	assertEqual(t, values[0], 1)
+	assertEqual(t, values[1], 4)
+}
+
+func TestBuffer(t *testing.T) {
+	t.Parallel()
+
+	source := observable.NewSubject()
+	notifier := observable.NewSubject()
+	buffered := collect(source.Buffer(notifier.Observable))
+
+	source.Next(1)
+	source.Next(2)
+	notifier.Next(nil)
+	notifier.Next(nil)
+	source.Next(3)
+	source.Complete()
+
+	result, _ := buffered.Wait()
+	values := result.([]any)
+
+	assertEqual(t, len(values), 3)
+	assertEqual(t, len(values[0].([]any)), 2)
+	assertEqual(t, values[0].([]any)[1], 2)
+	assertEqual(t, len(values[1].([]any)), 0)
+	assertEqual(t, len(values[2].([]any)), 1)
+	assertEqual(t, values[2].([]any)[0], 3)
+}
+
+func TestUnsubscribe(t *testing.T) {
+	t.Parallel()
+
+	subject := observable.NewSubject()
+	torndown := 0
+	received := []any{}
+
+	subscription := observable.New(func(observer *observable.Observer) observable.ObservableTeardown {
+		inner := subject.Subscribe(observer.Next, observer.Error, observer.Complete)
+
+		return func() {
+			torndown++
+			inner.Unsubscribe()
+		}
+	}).Subscribe(func(value any) {
+		received = append(received, value)
+	}, nil, nil)
+
+	subject.Next(1)
+	subscription.Unsubscribe()
+	subscription.Unsubscribe()
+	subject.Next(2)
+
+	assertEqual(t, torndown, 1)
+	assertEqual(t, subscription.Closed(), true)
+	assertEqual(t, len(received), 1)
+	assertEqual(t, received[0], 1)
+}
+
+func TestSubjectError(t *testing.T) {
+	t.Parallel()
+
+	dummyReason := errors.New("failed")
+
+	subject := observable.NewSubject()
+	early := collect(subject.Observable)
+
+	subject.Next(1)
+	subject.Error(dummyReason)
+	subject.Next(2)
+	subject.Complete()
+
+	_, reason := early.Wait()
+	assertEqual(t, reason, dummyReason)
+
+	_, reason = collect(subject.Observable).Wait()
+	assertEqual(t, reason, dummyReason)
+}
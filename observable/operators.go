@@ -0,0 +1,332 @@
+package observable
+
+import (
+	"sync"
+	"time"
+)
+
+func (self *Observable) Map(fn func(value any) any) *Observable {
+	return New(func(observer *Observer) ObservableTeardown {
+		return self.Subscribe(func(value any) {
+			observer.Next(fn(value))
+		}, observer.Error, observer.Complete).Unsubscribe
+	})
+}
+
+func (self *Observable) Filter(fn func(value any) bool) *Observable {
+	return New(func(observer *Observer) ObservableTeardown {
+		return self.Subscribe(func(value any) {
+			if fn(value) {
+				observer.Next(value)
+			}
+		}, observer.Error, observer.Complete).Unsubscribe
+	})
+}
+
+func (self *Observable) Take(count int) *Observable {
+	return New(func(observer *Observer) ObservableTeardown {
+		if count <= 0 {
+			observer.Complete()
+			return nil
+		}
+
+		mutex := sync.Mutex{}
+		taken := 0
+
+		return self.Subscribe(func(value any) {
+			mutex.Lock()
+			taken++
+			current := taken
+			mutex.Unlock()
+
+			if current <= count {
+				observer.Next(value)
+			}
+
+			if current == count {
+				observer.Complete()
+			}
+		}, observer.Error, observer.Complete).Unsubscribe
+	})
+}
+
+func (self *Observable) SwitchMap(fn func(value any) *Observable) *Observable {
+	return New(func(observer *Observer) ObservableTeardown {
+		mutex := sync.Mutex{}
+		var inner *Subscription
+		generation := 0
+		outerDone := false
+		innerDone := true
+
+		outer := self.Subscribe(func(value any) {
+			mutex.Lock()
+			previous := inner
+			generation++
+			current := generation
+			innerDone = false
+			mutex.Unlock()
+
+			if previous != nil {
+				previous.Unsubscribe()
+			}
+
+			subscription := fn(value).Subscribe(func(value any) {
+				mutex.Lock()
+				active := current == generation
+				mutex.Unlock()
+
+				if active {
+					observer.Next(value)
+				}
+			}, observer.Error, func() {
+				mutex.Lock()
+				active := current == generation
+				if active {
+					innerDone = true
+				}
+				done := active && outerDone
+				mutex.Unlock()
+
+				if done {
+					observer.Complete()
+				}
+			})
+
+			mutex.Lock()
+			if current == generation {
+				inner = subscription
+				mutex.Unlock()
+			} else {
+				mutex.Unlock()
+				subscription.Unsubscribe()
+			}
+		}, observer.Error, func() {
+			mutex.Lock()
+			outerDone = true
+			done := innerDone
+			mutex.Unlock()
+
+			if done {
+				observer.Complete()
+			}
+		})
+
+		return func() {
+			outer.Unsubscribe()
+
+			mutex.Lock()
+			current := inner
+			inner = nil
+			generation++
+			mutex.Unlock()
+
+			if current != nil {
+				current.Unsubscribe()
+			}
+		}
+	})
+}
+
+func (self *Observable) Debounce(wait time.Duration) *Observable {
+	return New(func(observer *Observer) ObservableTeardown {
+		mutex := sync.Mutex{}
+		var timer *time.Timer
+		var latest any
+		pending := false
+
+		flush := func() {
+			mutex.Lock()
+			value := latest
+			emit := pending
+			pending = false
+			latest = nil
+			mutex.Unlock()
+
+			if emit {
+				observer.Next(value)
+			}
+		}
+
+		subscription := self.Subscribe(func(value any) {
+			mutex.Lock()
+			latest = value
+			pending = true
+
+			if timer != nil {
+				timer.Stop()
+			}
+
+			timer = time.AfterFunc(wait, flush)
+			mutex.Unlock()
+		}, func(reason error) {
+			mutex.Lock()
+			if timer != nil {
+				timer.Stop()
+			}
+			pending = false
+			mutex.Unlock()
+
+			observer.Error(reason)
+		}, func() {
+			mutex.Lock()
+			if timer != nil {
+				timer.Stop()
+			}
+			mutex.Unlock()
+
+			flush()
+			observer.Complete()
+		})
+
+		return func() {
+			subscription.Unsubscribe()
+
+			mutex.Lock()
+			if timer != nil {
+				timer.Stop()
+			}
+			pending = false
+			mutex.Unlock()
+		}
+	})
+}
+
+func (self *Observable) Throttle(interval time.Duration) *Observable {
+	return New(func(observer *Observer) ObservableTeardown {
+		mutex := sync.Mutex{}
+		var last time.Time
+
+		return self.Subscribe(func(value any) {
+			now := time.Now()
+
+			mutex.Lock()
+			emit := last.IsZero() || now.Sub(last) >= interval
+			if emit {
+				last = now
+			}
+			mutex.Unlock()
+
+			if emit {
+				observer.Next(value)
+			}
+		}, observer.Error, observer.Complete).Unsubscribe
+	})
+}
+
+func (self *Observable) Buffer(notifier *Observable) *Observable {
+	return New(func(observer *Observer) ObservableTeardown {
+		mutex := sync.Mutex{}
+		buffer := []any{}
+
+		flush := func() {
+			mutex.Lock()
+			values := buffer
+			buffer = []any{}
+			mutex.Unlock()
+
+			observer.Next(values)
+		}
+
+		closing := notifier.Subscribe(func(_ any) {
+			flush()
+		}, observer.Error, nil)
+
+		source := self.Subscribe(func(value any) {
+			mutex.Lock()
+			buffer = append(buffer, value)
+			mutex.Unlock()
+		}, observer.Error, func() {
+			flush()
+			observer.Complete()
+		})
+
+		return func() {
+			closing.Unsubscribe()
+			source.Unsubscribe()
+		}
+	})
+}
+
+func Merge(sources ...*Observable) *Observable {
+	return New(func(observer *Observer) ObservableTeardown {
+		if len(sources) == 0 {
+			observer.Complete()
+			return nil
+		}
+
+		mutex := sync.Mutex{}
+		remaining := len(sources)
+		subscriptions := make([]*Subscription, 0, len(sources))
+
+		for _, source := range sources {
+			subscription := source.Subscribe(func(value any) {
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				observer.Next(value)
+			}, observer.Error, func() {
+				mutex.Lock()
+				remaining--
+				done := remaining == 0
+				mutex.Unlock()
+
+				if done {
+					observer.Complete()
+				}
+			})
+
+			subscriptions = append(subscriptions, subscription)
+		}
+
+		return func() {
+			for _, subscription := range subscriptions {
+				subscription.Unsubscribe()
+			}
+		}
+	})
+}
+
+func Concat(sources ...*Observable) *Observable {
+	return New(func(observer *Observer) ObservableTeardown {
+		mutex := sync.Mutex{}
+		var current *Subscription
+		stopped := false
+
+		var subscribe func(index int)
+		subscribe = func(index int) {
+			if index >= len(sources) {
+				observer.Complete()
+				return
+			}
+
+			subscription := sources[index].Subscribe(observer.Next, observer.Error, func() {
+				subscribe(index + 1)
+			})
+
+			mutex.Lock()
+			if stopped {
+				mutex.Unlock()
+				subscription.Unsubscribe()
+				return
+			}
+			if !subscription.Closed() {
+				current = subscription
+			}
+			mutex.Unlock()
+		}
+
+		subscribe(0)
+
+		return func() {
+			mutex.Lock()
+			stopped = true
+			subscription := current
+			current = nil
+			mutex.Unlock()
+
+			if subscription != nil {
+				subscription.Unsubscribe()
+			}
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package oteltracer
+
+import (
+	"context"
+	"sync"
+
+	promise "github.com/eolme/go-promise/promise"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Tracer struct {
+	tracer trace.Tracer
+	spans  sync.Map
+}
+
+func New(tracer trace.Tracer) (adapter *Tracer) {
+	adapter = &Tracer{
+		tracer: tracer,
+	}
+
+	return adapter
+}
+
+func (self *Tracer) Start(ctx context.Context, span *promise.PromiseSpan) context.Context {
+	parents := make([]int64, len(span.Parents))
+	for index, parent := range span.Parents {
+		parents[index] = int64(parent)
+	}
+
+	ctx, started := self.tracer.Start(ctx, "promise."+span.Operation,
+		trace.WithTimestamp(span.Created),
+		trace.WithAttributes(
+			attribute.Int64("promise.id", int64(span.ID)),
+			attribute.Int64Slice("promise.parents", parents),
+			attribute.String("promise.site", span.Site),
+		),
+	)
+
+	self.spans.Store(span.ID, started)
+
+	return ctx
+}
+
+func (self *Tracer) End(span *promise.PromiseSpan) {
+	stored, ok := self.spans.LoadAndDelete(span.ID)
+	if !ok {
+		return
+	}
+
+	ended := stored.(trace.Span)
+
+	ended.SetAttributes(
+		attribute.String("promise.status", string(span.Status)),
+		attribute.Int64("promise.pending_ns", span.Pending().Nanoseconds()),
+		attribute.Int64("promise.handler_ns", span.Handler().Nanoseconds()),
+	)
+
+	if span.Status == promise.PromiseStatusRejected {
+		if span.Reason != nil {
+			ended.RecordError(span.Reason)
+			ended.SetStatus(codes.Error, span.Reason.Error())
+		} else {
+			ended.SetStatus(codes.Error, "rejected")
+		}
+	}
+
+	ended.End(trace.WithTimestamp(span.Settled))
+}
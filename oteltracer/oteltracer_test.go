@@ -0,0 +1,65 @@
+package oteltracer_test
+
+import (
+	"errors"
+	"testing"
+
+	oteltracer "github.com/eolme/go-promise/oteltracer"
+	promise "github.com/eolme/go-promise/promise"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func assertEqual(t *testing.T, actual any, expected any) {
+	if actual == expected {
+		t.Logf("Assertion success: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	} else {
+		t.Errorf("Assertion fail: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	}
+}
+
+func findAttribute(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, current := range span.Attributes {
+		if current.Key == key {
+			return current.Value
+		}
+	}
+
+	return attribute.Value{}
+}
+
+func TestTracer(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	promise.SetTracer(oteltracer.New(provider.Tracer("promise")))
+	defer promise.SetTracer(nil)
+
+	expected := errors.New("unavailable")
+
+	root := promise.Resolve(1)
+	root.Then(func(result any) (any, error) {
+		return nil, expected
+	}).Wait()
+
+	spans := exporter.GetSpans()
+	assertEqual(t, len(spans), 2)
+
+	resolved, chained := spans[0], spans[1]
+	if resolved.Name != "promise.Resolve" {
+		resolved, chained = chained, resolved
+	}
+
+	assertEqual(t, resolved.Name, "promise.Resolve")
+	assertEqual(t, findAttribute(resolved, "promise.status").AsString(), string(promise.PromiseStatusFulfilled))
+	assertEqual(t, resolved.Status.Code, codes.Unset)
+
+	assertEqual(t, chained.Name, "promise.Then")
+	assertEqual(t, chained.Parent.SpanID(), resolved.SpanContext.SpanID())
+	assertEqual(t, findAttribute(chained, "promise.status").AsString(), string(promise.PromiseStatusRejected))
+	assertEqual(t, chained.Status.Code, codes.Error)
+	assertEqual(t, chained.Status.Description, expected.Error())
+	assertEqual(t, len(chained.Events), 1)
+}
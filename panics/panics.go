@@ -1,34 +1,22 @@
 package panics
 
 import (
-	"errors"
-	"fmt"
-
+	async "github.com/eolme/go-promise/async"
 	promise "github.com/eolme/go-promise/promise"
 )
 
 type FunctionWithPanic func() any
 
-func wrapPanic(value any) error {
-	if err, ok := value.(error); ok {
-		return err
-	}
-
-	if str, ok := value.(string); ok {
-		return errors.New(str)
-	}
-
-	return errors.New(fmt.Sprintf("panic handled with %p", &value))
-}
+type PanicError = promise.PromisePanicError
 
 func PromisifyPanic(fn FunctionWithPanic) *promise.Promise {
-	return promise.New(func(resolve promise.PromiseResolve, reject promise.PromiseReject) {
-		defer func() {
-			if value := recover(); value != nil {
-				reject(wrapPanic(value))
-			}
-		}()
+	return promise.Try(func() (any, error) {
+		return fn(), nil
+	})
+}
 
-		resolve(fn())
+func AsyncPanic(fn async.AsyncFunction) *promise.Promise {
+	return async.Async(func() (any, error) {
+		return promise.Try(fn), nil
 	})
 }
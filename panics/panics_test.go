@@ -0,0 +1,71 @@
+package panics_test
+
+import (
+	"errors"
+	"testing"
+
+	panics "github.com/eolme/go-promise/panics"
+)
+
+func assertEqual(t *testing.T, actual any, expected any) {
+	if actual == expected {
+		t.Logf("Assertion success: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	} else {
+		t.Errorf("Assertion fail: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	}
+}
+
+func TestPromisifyPanic(t *testing.T) {
+	t.Parallel()
+
+	result, reason := panics.PromisifyPanic(func() any {
+		return 1
+	}).Wait()
+
+	assertEqual(t, result, 1)
+	assertEqual(t, reason, nil)
+}
+
+func TestPromisifyPanicKeepsErrors(t *testing.T) {
+	t.Parallel()
+
+	expected := errors.New("something went wrong")
+
+	_, reason := panics.PromisifyPanic(func() any {
+		panic(expected)
+	}).Wait()
+
+	assertEqual(t, reason, expected)
+}
+
+func TestPromisifyPanicWrapsValues(t *testing.T) {
+	t.Parallel()
+
+	_, reason := panics.PromisifyPanic(func() any {
+		panic("something went wrong")
+	}).Wait()
+
+	var panicked panics.PanicError
+	assertEqual(t, errors.As(reason, &panicked), true)
+	assertEqual(t, panicked.Value, "something went wrong")
+	assertEqual(t, reason.Error(), "something went wrong")
+}
+
+func TestAsyncPanic(t *testing.T) {
+	t.Parallel()
+
+	expected := errors.New("something went wrong")
+
+	_, reason := panics.AsyncPanic(func() (any, error) {
+		panic(expected)
+	}).Wait()
+
+	assertEqual(t, reason, expected)
+
+	result, reason := panics.AsyncPanic(func() (any, error) {
+		return 1, nil
+	}).Wait()
+
+	assertEqual(t, result, 1)
+	assertEqual(t, reason, nil)
+}
@@ -0,0 +1,81 @@
+package promise
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+var ErrChannelClosed = errors.New("Channel closed without value")
+
+func FromChannel[T any](ch <-chan T) (promise *Promise) {
+	promise = createPromise("FromChannel")
+
+	spawn(promise, func() {
+		value, ok := <-ch
+
+		if ok {
+			assignPromise(promise, value)
+		} else {
+			rejectPromise(promise, ErrChannelClosed)
+		}
+	})
+
+	return promise
+}
+
+func FromErrChannel(ch <-chan error) (promise *Promise) {
+	promise = createPromise("FromErrChannel")
+
+	spawn(promise, func() {
+		if reason := <-ch; reason != nil {
+			rejectPromise(promise, reason)
+		} else {
+			fulfillPromise(promise, nil)
+		}
+	})
+
+	return promise
+}
+
+func Collect[T any](ch <-chan T) (promise *Promise) {
+	promise = createPromise("Collect")
+
+	spawn(promise, func() {
+		all := []T{}
+
+		for value := range ch {
+			all = append(all, value)
+		}
+
+		fulfillPromise(promise, all)
+	})
+
+	return promise
+}
+
+func (self *Promise) ToChannel() <-chan PromiseSettled {
+	markObserved(self)
+
+	ch := make(chan PromiseSettled, 1)
+
+	spawn(self, func() {
+		<-self.wait
+
+		switch atomic.LoadUint32(&self.status) {
+		case internalFulfilled:
+			ch <- PromiseSettled{
+				Status: PromiseStatusFulfilled,
+				Value:  self.fulfilled,
+			}
+		case internalRejected:
+			ch <- PromiseSettled{
+				Status: PromiseStatusRejected,
+				Reason: self.rejected,
+			}
+		}
+
+		close(ch)
+	})
+
+	return ch
+}
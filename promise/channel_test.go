@@ -0,0 +1,84 @@
+package promise_test
+
+import (
+	"testing"
+
+	promise "github.com/eolme/go-promise/promise"
+)
+
+func TestChannel(t *testing.T) {
+	testPrepare(t)
+
+	testAsync(t, "fulfills with the first value", func(t *testing.T, done func()) {
+		ch := make(chan int, 2)
+		ch <- 1
+		ch <- 2
+
+		result, reason := promise.FromChannel(ch).Wait()
+		assertEqual(t, result, 1)
+		assertEqual(t, reason, nil)
+		done()
+	})
+
+	testAsync(t, "rejects when closed without value", func(t *testing.T, done func()) {
+		ch := make(chan int)
+		close(ch)
+
+		_, reason := promise.FromChannel(ch).Wait()
+		assertEqual(t, reason, promise.ErrChannelClosed)
+		done()
+	})
+
+	testAsync(t, "rejects with a received error", func(t *testing.T, done func()) {
+		dummyReason := createDummyReason()
+		ch := make(chan error, 1)
+		ch <- dummyReason
+
+		_, reason := promise.FromErrChannel(ch).Wait()
+		assertEqual(t, reason, dummyReason)
+		done()
+	})
+
+	testAsync(t, "fulfills on a single nil send", func(t *testing.T, done func()) {
+		ch := make(chan error)
+
+		go func() {
+			ch <- nil
+		}()
+
+		_, reason := promise.FromErrChannel(ch).Wait()
+		assertEqual(t, reason, nil)
+		done()
+	})
+
+	testAsync(t, "fulfills when closed without value", func(t *testing.T, done func()) {
+		ch := make(chan error)
+		close(ch)
+
+		_, reason := promise.FromErrChannel(ch).Wait()
+		assertEqual(t, reason, nil)
+		done()
+	})
+
+	testAsync(t, "collects all values", func(t *testing.T, done func()) {
+		ch := make(chan int, 3)
+		ch <- 1
+		ch <- 2
+		ch <- 3
+		close(ch)
+
+		result, _ := promise.Collect(ch).Wait()
+		assertEqual(t, len(result.([]int)), 3)
+		done()
+	})
+
+	testRejected(t, createDummyReason(), func(t *testing.T, instance *promise.Promise, done func()) {
+		ch := instance.ToChannel()
+
+		go func() {
+			settled := <-ch
+			assertEqual(t, settled.Status, promise.PromiseStatusRejected)
+			done()
+		}()
+	})
+}
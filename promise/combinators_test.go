@@ -0,0 +1,77 @@
+package promise_test
+
+import (
+	"testing"
+
+	promise "github.com/eolme/go-promise/promise"
+)
+
+const combinatorRounds = 200
+
+func createSettledInputs(length int) []any {
+	arr := make([]any, length)
+
+	for index := range arr {
+		if index%2 == 0 {
+			arr[index] = promise.Resolve(index)
+		} else {
+			arr[index] = promise.Reject(createDummyReason())
+		}
+	}
+
+	return arr
+}
+
+func TestCombinatorsSettleAfterEveryInput(t *testing.T) {
+	testPrepare(t)
+
+	testAsync(t, "All sees every fulfilled value", func(t *testing.T, done func()) {
+		for round := 0; round < combinatorRounds; round++ {
+			arr := make([]any, 16)
+			for index := range arr {
+				arr[index] = promise.Resolve(index)
+			}
+
+			result, _ := promise.All(arr).Wait()
+
+			for index, value := range result.([]any) {
+				if value != index {
+					t.Fatalf("round %d: slot %d is %v", round, index, value)
+				}
+			}
+		}
+
+		done()
+	})
+
+	testAsync(t, "AllSettled sees every outcome", func(t *testing.T, done func()) {
+		for round := 0; round < combinatorRounds; round++ {
+			result, _ := promise.AllSettled(createSettledInputs(16)).Wait()
+
+			for index, settled := range result.([]promise.PromiseSettled) {
+				if settled.Status == "" {
+					t.Fatalf("round %d: slot %d is empty", round, index)
+				}
+			}
+		}
+
+		done()
+	})
+
+	testAsync(t, "Any aggregates every reason", func(t *testing.T, done func()) {
+		for round := 0; round < combinatorRounds; round++ {
+			arr := make([]any, 16)
+			for index := range arr {
+				arr[index] = promise.Reject(createDummyReason())
+			}
+
+			_, reason := promise.Any(arr).Wait()
+
+			if errors := reason.(promise.PromiseAggregateError).Errors; len(errors) != len(arr) {
+				t.Fatalf("round %d: aggregated %d of %d reasons", round, len(errors), len(arr))
+			}
+		}
+
+		done()
+	})
+}
@@ -0,0 +1,95 @@
+package promise
+
+import (
+	"errors"
+	"time"
+)
+
+type PromiseHedged struct {
+	Attempt int
+	Value   any
+}
+
+type hedgeResult struct {
+	attempt  int
+	unpacked any
+	reason   error
+}
+
+func Hedge(fn func(attempt int) *Promise, delay time.Duration, max int) (promise *Promise) {
+	promise = createPromise("Hedge")
+
+	if max < 1 {
+		max = 1
+	}
+
+	spawn(promise, func() {
+		results := make(chan hedgeResult, max)
+		started := 0
+
+		start := func() {
+			attempt := started
+			started++
+
+			go func() {
+				unpacked, reason := unpackPromise(fn(attempt))
+				results <- hedgeResult{
+					attempt:  attempt,
+					unpacked: unpacked,
+					reason:   reason,
+				}
+			}()
+		}
+
+		start()
+
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		aggregated := []error{}
+
+		for {
+			select {
+			case <-timer.C:
+				if started < max {
+					start()
+					timer.Reset(delay)
+				}
+			case result := <-results:
+				if result.reason == nil {
+					fulfillPromise(promise, PromiseHedged{
+						Attempt: result.attempt,
+						Value:   result.unpacked,
+					})
+
+					return
+				}
+
+				aggregated = append(aggregated, result.reason)
+
+				if len(aggregated) == max {
+					rejectPromise(promise, PromiseAggregateError{
+						error:  errors.New("All hedged attempts were rejected"),
+						Errors: aggregated,
+					})
+
+					return
+				}
+
+				if len(aggregated) == started {
+					start()
+
+					if !timer.Stop() {
+						select {
+						case <-timer.C:
+						default:
+						}
+					}
+					timer.Reset(delay)
+				}
+			}
+		}
+	})
+
+	return promise
+}
@@ -0,0 +1,54 @@
+package promise_test
+
+import (
+	"testing"
+	"time"
+
+	promise "github.com/eolme/go-promise/promise"
+)
+
+func TestHedge(t *testing.T) {
+	testPrepare(t)
+
+	testAsync(t, "resolves with the first successful attempt", func(t *testing.T, done func()) {
+		dummyValue := createDummyValue()
+
+		result, reason := promise.Hedge(func(attempt int) *promise.Promise {
+			if attempt == 0 {
+				return promise.New(func(_ promise.PromiseResolve, _ promise.PromiseReject) {})
+			}
+
+			return promise.Resolve(dummyValue)
+		}, 20*time.Millisecond, 3).Wait()
+
+		assertEqual(t, reason, nil)
+		assertEqual(t, result.(promise.PromiseHedged).Attempt, 1)
+		assertEqual(t, result.(promise.PromiseHedged).Value, dummyValue)
+		done()
+	})
+
+	testAsync(t, "starts the next attempt immediately after a rejection", func(t *testing.T, done func()) {
+		started := time.Now()
+
+		result, _ := promise.Hedge(func(attempt int) *promise.Promise {
+			if attempt == 0 {
+				return promise.Reject(createDummyReason())
+			}
+
+			return promise.Resolve(attempt)
+		}, time.Second, 2).Wait()
+
+		assertEqual(t, result.(promise.PromiseHedged).Attempt, 1)
+		assertEqual(t, time.Since(started) < time.Second, true)
+		done()
+	})
+
+	testAsync(t, "rejects when every attempt rejects", func(t *testing.T, done func()) {
+		_, reason := promise.Hedge(func(attempt int) *promise.Promise {
+			return promise.Reject(createDummyReason())
+		}, 10*time.Millisecond, 3).Wait()
+
+		assertEqual(t, len(reason.(promise.PromiseAggregateError).Errors), 3)
+		done()
+	})
+}
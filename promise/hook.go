@@ -0,0 +1,131 @@
+package promise
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const PromiseStatusPending PromiseStatus = "pending"
+
+type PromiseHook interface {
+	Created(info PromiseInfo)
+	Settled(info PromiseInfo)
+}
+
+type PromiseInfo struct {
+	ID        uint64
+	Parents   []uint64
+	Operation string
+	Created   time.Time
+	Settled   time.Time
+	Status    PromiseStatus
+	Value     any
+	Reason    error
+	Panicked  bool
+}
+
+type hookOrigin struct {
+	operation string
+	parents   []uint64
+	created   time.Time
+	hooks     []PromiseHook
+}
+
+var (
+	hooksMutex   sync.Mutex
+	currentHooks atomic.Value
+)
+
+func AddHook(hook PromiseHook) {
+	hooksMutex.Lock()
+	defer hooksMutex.Unlock()
+
+	hooks, _ := currentHooks.Load().([]PromiseHook)
+	currentHooks.Store(append(append([]PromiseHook{}, hooks...), hook))
+}
+
+func RemoveHook(hook PromiseHook) {
+	hooksMutex.Lock()
+	defer hooksMutex.Unlock()
+
+	hooks, _ := currentHooks.Load().([]PromiseHook)
+	filtered := make([]PromiseHook, 0, len(hooks))
+
+	for _, current := range hooks {
+		if current != hook {
+			filtered = append(filtered, current)
+		}
+	}
+
+	currentHooks.Store(filtered)
+}
+
+func hookCreate(promise *Promise, operation string, parents []any) {
+	hooks, _ := currentHooks.Load().([]PromiseHook)
+	if len(hooks) == 0 {
+		return
+	}
+
+	origin := &hookOrigin{
+		operation: operation,
+		parents:   parentIDs(parents),
+		created:   time.Now(),
+		hooks:     hooks,
+	}
+
+	promise.origin = origin
+
+	info := PromiseInfo{
+		ID:        promise.id,
+		Parents:   origin.parents,
+		Operation: operation,
+		Created:   origin.created,
+		Status:    PromiseStatusPending,
+	}
+
+	for _, hook := range hooks {
+		hook.Created(info)
+	}
+}
+
+func hookSettle(promise *Promise) {
+	origin := promise.origin
+	if origin == nil {
+		return
+	}
+
+	info := PromiseInfo{
+		ID:        promise.id,
+		Parents:   origin.parents,
+		Operation: origin.operation,
+		Created:   origin.created,
+		Settled:   time.Now(),
+	}
+
+	switch atomic.LoadUint32(&promise.status) {
+	case internalFulfilled:
+		info.Status = PromiseStatusFulfilled
+		info.Value = promise.fulfilled
+	case internalRejected:
+		info.Status = PromiseStatusRejected
+		info.Reason = promise.rejected
+		info.Panicked = promise.panicked
+	}
+
+	for _, hook := range origin.hooks {
+		hook.Settled(info)
+	}
+}
+
+func parentIDs(parents []any) (ids []uint64) {
+	ids = []uint64{}
+
+	for _, parent := range parents {
+		if parent, ok := parent.(*Promise); ok {
+			ids = append(ids, parent.id)
+		}
+	}
+
+	return ids
+}
@@ -0,0 +1,41 @@
+package promise
+
+import (
+	"context"
+	"runtime/pprof"
+)
+
+func hasLabels(ctx context.Context) (labeled bool) {
+	pprof.ForLabels(ctx, func(_ string, _ string) bool {
+		labeled = true
+		return false
+	})
+
+	return labeled
+}
+
+func inheritLabels(ctx context.Context, parents []any) bool {
+	if ctx != nil {
+		return hasLabels(ctx)
+	}
+
+	for _, parent := range parents {
+		if promise, ok := parent.(*Promise); ok {
+			return promise.labeled
+		}
+	}
+
+	return false
+}
+
+func spawn(promise *Promise, fn func()) {
+	if !promise.labeled {
+		go fn()
+		return
+	}
+
+	go func() {
+		pprof.SetGoroutineLabels(promise.context)
+		fn()
+	}()
+}
@@ -0,0 +1,40 @@
+package promise_test
+
+import (
+	"bytes"
+	"context"
+	"runtime/pprof"
+	"strings"
+	"testing"
+
+	promise "github.com/eolme/go-promise/promise"
+)
+
+func TestLabels(t *testing.T) {
+	testPrepare(t)
+
+	testAsync(t, "handlers run with the labels of the context", func(t *testing.T, done func()) {
+		var root *promise.Promise
+
+		pprof.Do(context.Background(), pprof.Labels("job", "import"), func(ctx context.Context) {
+			root = promise.NewWithContext(ctx, func(resolve promise.PromiseResolve, _ promise.PromiseReject) {
+				resolve(nil)
+			})
+		})
+
+		root.Then(func(_ any) (any, error) {
+			return nil, nil
+		}).Then(func(_ any) (any, error) {
+			profile := bytes.Buffer{}
+			pprof.Lookup("goroutine").WriteTo(&profile, 1)
+
+			assertEqual(t, strings.Contains(profile.String(), `"job":"import"`), true)
+
+			return nil, nil
+		}).Wait()
+
+		label, _ := pprof.Label(root.Context(), "job")
+		assertEqual(t, label, "import")
+		done()
+	})
+}
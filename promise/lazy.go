@@ -0,0 +1,30 @@
+package promise
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+func Lazy(fn func(resolve PromiseResolve, reject PromiseReject)) (promise *Promise) {
+	return LazyWithContext(nil, fn)
+}
+
+func LazyWithContext(ctx context.Context, fn func(resolve PromiseResolve, reject PromiseReject)) (promise *Promise) {
+	promise = createPromiseWithContext(ctx, "Lazy")
+
+	promise.lazy = func() {
+		fn(func(result any) {
+			assignPromise(promise, result)
+		}, func(reason error) {
+			rejectPromise(promise, reason)
+		})
+	}
+
+	return promise
+}
+
+func startLazy(promise *Promise) {
+	if promise.lazy != nil && atomic.CompareAndSwapUint32(&promise.started, 0, 1) {
+		promise.lazy()
+	}
+}
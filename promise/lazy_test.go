@@ -0,0 +1,66 @@
+package promise_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	promise "github.com/eolme/go-promise/promise"
+)
+
+func TestLazy(t *testing.T) {
+	testPrepare(t)
+
+	createLazy := func(started *uint32, value any) *promise.Promise {
+		return promise.Lazy(func(resolve promise.PromiseResolve, _ promise.PromiseReject) {
+			atomic.AddUint32(started, 1)
+			resolve(value)
+		})
+	}
+
+	testAsync(t, "does not run until observed", func(t *testing.T, done func()) {
+		started := uint32(0)
+		createLazy(&started, nil)
+
+		time.Sleep(10 * time.Millisecond)
+
+		assertEqual(t, atomic.LoadUint32(&started), uint32(0))
+		done()
+	})
+
+	testAsync(t, "runs once on Wait", func(t *testing.T, done func()) {
+		started := uint32(0)
+		dummyValue := createDummyValue()
+		instance := createLazy(&started, dummyValue)
+
+		result, _ := instance.Wait()
+		instance.Wait()
+
+		assertEqual(t, result, dummyValue)
+		assertEqual(t, atomic.LoadUint32(&started), uint32(1))
+		done()
+	})
+
+	testAsync(t, "runs on Then", func(t *testing.T, done func()) {
+		started := uint32(0)
+		dummyValue := createDummyValue()
+
+		createLazy(&started, dummyValue).Then(func(result any) (any, error) {
+			assertEqual(t, result, dummyValue)
+			assertEqual(t, atomic.LoadUint32(&started), uint32(1))
+			done()
+
+			return nil, nil
+		})
+	})
+
+	testAsync(t, "runs on combinator subscription", func(t *testing.T, done func()) {
+		started := uint32(0)
+
+		result, _ := promise.All([]any{createLazy(&started, 1), createLazy(&started, 2)}).Wait()
+
+		assertEqual(t, result.([]any)[1], 2)
+		assertEqual(t, atomic.LoadUint32(&started), uint32(2))
+		done()
+	})
+}
@@ -0,0 +1,110 @@
+package promise
+
+import (
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+type loggerHolder struct {
+	logger *slog.Logger
+}
+
+var (
+	currentLogger    atomic.Value
+	unhandledTimeout int64
+)
+
+func WithLogger(logger *slog.Logger) {
+	currentLogger.Store(loggerHolder{
+		logger: logger,
+	})
+}
+
+func LogUnhandledRejections(delay time.Duration) {
+	atomic.StoreInt64(&unhandledTimeout, int64(delay))
+}
+
+func (self *Promise) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.Uint64("id", self.id),
+	}
+
+	select {
+	case <-self.wait:
+		switch atomic.LoadUint32(&self.status) {
+		case internalFulfilled:
+			attrs = append(attrs, slog.String("state", string(PromiseStatusFulfilled)), slog.Any("value", self.fulfilled))
+		case internalRejected:
+			attrs = append(attrs, slog.String("state", string(PromiseStatusRejected)), slog.Any("reason", self.rejected))
+		}
+	default:
+		attrs = append(attrs, slog.String("state", string(PromiseStatusPending)))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+func (self *Promise) String() string {
+	select {
+	case <-self.wait:
+		switch atomic.LoadUint32(&self.status) {
+		case internalFulfilled:
+			return fmt.Sprintf("Promise#%d %s: %v", self.id, PromiseStatusFulfilled, self.fulfilled)
+		case internalRejected:
+			return fmt.Sprintf("Promise#%d %s: %v", self.id, PromiseStatusRejected, self.rejected)
+		}
+	default:
+	}
+
+	return fmt.Sprintf("Promise#%d %s", self.id, PromiseStatusPending)
+}
+
+func (self PromiseSettled) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("state", string(self.Status)),
+	}
+
+	if self.Status == PromiseStatusRejected {
+		attrs = append(attrs, slog.Any("reason", self.Reason))
+	} else {
+		attrs = append(attrs, slog.Any("value", self.Value))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+func loadLogger() *slog.Logger {
+	holder, _ := currentLogger.Load().(loggerHolder)
+
+	return holder.logger
+}
+
+func markObserved(promise *Promise) {
+	atomic.StoreUint32(&promise.observed, 1)
+	startLazy(promise)
+}
+
+func watchRejection(promise *Promise) {
+	delay := time.Duration(atomic.LoadInt64(&unhandledTimeout))
+	if delay <= 0 {
+		return
+	}
+
+	time.AfterFunc(delay, func() {
+		if atomic.LoadUint32(&promise.observed) == 1 {
+			return
+		}
+
+		Logger().Error("Unhandled promise rejection", slog.Any("promise", promise))
+	})
+}
+
+func Logger() *slog.Logger {
+	if logger := loadLogger(); logger != nil {
+		return logger
+	}
+
+	return slog.Default()
+}
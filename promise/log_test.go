@@ -0,0 +1,74 @@
+package promise_test
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	promise "github.com/eolme/go-promise/promise"
+)
+
+type syncBuffer struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (self *syncBuffer) Write(p []byte) (int, error) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return self.buffer.Write(p)
+}
+
+func (self *syncBuffer) String() string {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return self.buffer.String()
+}
+
+func TestLogger(t *testing.T) {
+	output := &syncBuffer{}
+
+	promise.WithLogger(slog.New(slog.NewTextHandler(output, nil)))
+	promise.LogUnhandledRejections(20 * time.Millisecond)
+
+	defer promise.WithLogger(nil)
+	defer promise.LogUnhandledRejections(0)
+
+	handled := promise.Reject(createDummyReason())
+	handled.Catch(func(_ error) (any, error) {
+		return nil, nil
+	}).Wait()
+
+	unhandled := promise.Reject(createDummyReason())
+
+	time.Sleep(50 * time.Millisecond)
+
+	logged := output.String()
+
+	assertEqual(t, strings.Count(logged, "Unhandled promise rejection"), 1)
+	assertEqual(t, strings.Contains(logged, "promise.state=rejected"), true)
+	assertEqual(t, strings.HasPrefix(fmt.Sprintf("%v", unhandled), "Promise#"), true)
+}
+
+func TestLoggerDefault(t *testing.T) {
+	output := &syncBuffer{}
+
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(output, nil)))
+	promise.LogUnhandledRejections(20 * time.Millisecond)
+
+	defer slog.SetDefault(previous)
+	defer promise.LogUnhandledRejections(0)
+
+	promise.Reject(createDummyReason())
+
+	time.Sleep(50 * time.Millisecond)
+
+	assertEqual(t, strings.Count(output.String(), "Unhandled promise rejection"), 1)
+}
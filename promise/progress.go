@@ -0,0 +1,125 @@
+package promise
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+type PromiseProgress func(value any)
+
+type promiseProgress struct {
+	mutex     sync.Mutex
+	listeners []PromiseProgress
+	last      any
+	reported  bool
+}
+
+func NewWithProgress(fn func(resolve PromiseResolve, reject PromiseReject, progress PromiseProgress)) (promise *Promise) {
+	return NewWithProgressContext(nil, fn)
+}
+
+func NewWithProgressContext(ctx context.Context, fn func(resolve PromiseResolve, reject PromiseReject, progress PromiseProgress)) (promise *Promise) {
+	promise = createPromiseWithContext(ctx, "NewWithProgress")
+
+	fn(func(result any) {
+		assignPromise(promise, result)
+	}, func(reason error) {
+		rejectPromise(promise, reason)
+	}, func(value any) {
+		emitProgress(promise, value)
+	})
+
+	return promise
+}
+
+func (self *Promise) OnProgress(fn PromiseProgress) (promise *Promise) {
+	if last, replay := subscribeProgress(self, fn); replay {
+		fn(last)
+	}
+
+	return self
+}
+
+func subscribeProgress(promise *Promise, fn PromiseProgress) (last any, replay bool) {
+	promise.progress.mutex.Lock()
+	defer promise.progress.mutex.Unlock()
+
+	if atomic.LoadUint32(&promise.status) != internalPending {
+		return nil, false
+	}
+
+	promise.progress.listeners = append(promise.progress.listeners, fn)
+
+	return promise.progress.last, promise.progress.reported
+}
+
+func emitProgress(promise *Promise, value any) {
+	promise.progress.mutex.Lock()
+
+	if atomic.LoadUint32(&promise.status) != internalPending {
+		promise.progress.mutex.Unlock()
+		return
+	}
+
+	promise.progress.last = value
+	promise.progress.reported = true
+	listeners := promise.progress.listeners
+
+	promise.progress.mutex.Unlock()
+
+	for _, listener := range listeners {
+		listener(value)
+	}
+}
+
+func settleProgress(promise *Promise) {
+	promise.progress.mutex.Lock()
+	promise.progress.listeners = nil
+	promise.progress.mutex.Unlock()
+}
+
+func forwardProgress(from *Promise, to *Promise) {
+	if last, replay := subscribeProgress(from, func(value any) {
+		emitProgress(to, value)
+	}); replay {
+		emitProgress(to, last)
+	}
+}
+
+type allProgress struct {
+	mutex     sync.Mutex
+	promise   *Promise
+	fractions []float64
+}
+
+func (self *allProgress) report(index int, fraction float64) {
+	if fraction < 0 {
+		fraction = 0
+	} else if fraction > 1 {
+		fraction = 1
+	}
+
+	self.mutex.Lock()
+
+	self.fractions[index] = fraction
+
+	total := float64(0)
+	for _, fraction := range self.fractions {
+		total += fraction
+	}
+
+	self.mutex.Unlock()
+
+	emitProgress(self.promise, total/float64(len(self.fractions)))
+}
+
+func (self *allProgress) watch(index int, value any) {
+	if promise, ok := value.(*Promise); ok {
+		promise.OnProgress(func(value any) {
+			if fraction, ok := value.(float64); ok {
+				self.report(index, fraction)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package promise_test
+
+import (
+	"sync"
+	"testing"
+
+	promise "github.com/eolme/go-promise/promise"
+)
+
+func TestProgress(t *testing.T) {
+	testPrepare(t)
+
+	testAsync(t, "notifies subscribers before settling", func(t *testing.T, done func()) {
+		var report promise.PromiseProgress
+		var resolve promise.PromiseResolve
+
+		instance := promise.NewWithProgress(func(res promise.PromiseResolve, _ promise.PromiseReject, progress promise.PromiseProgress) {
+			resolve, report = res, progress
+		})
+
+		values := []any{}
+		instance.OnProgress(func(value any) {
+			values = append(values, value)
+		})
+
+		report(0.5)
+		report(1.0)
+		resolve(nil)
+		report(2.0)
+
+		instance.Wait()
+
+		assertEqual(t, len(values), 2)
+		assertEqual(t, values[0], 0.5)
+		assertEqual(t, values[1], 1.0)
+		done()
+	})
+
+	testAsync(t, "forwards progress through Then chains", func(t *testing.T, done func()) {
+		var report promise.PromiseProgress
+		var resolve promise.PromiseResolve
+
+		instance := promise.NewWithProgress(func(res promise.PromiseResolve, _ promise.PromiseReject, progress promise.PromiseProgress) {
+			resolve, report = res, progress
+		})
+
+		received := make(chan any, 1)
+		chained := instance.Then(func(result any) (any, error) {
+			return result, nil
+		}).OnProgress(func(value any) {
+			received <- value
+		})
+
+		report("halfway")
+
+		assertEqual(t, <-received, "halfway")
+
+		resolve(nil)
+		chained.Wait()
+		done()
+	})
+
+	testAsync(t, "aggregates fraction complete in All", func(t *testing.T, done func()) {
+		var report promise.PromiseProgress
+		var resolve promise.PromiseResolve
+
+		slow := promise.NewWithProgress(func(res promise.PromiseResolve, _ promise.PromiseReject, progress promise.PromiseProgress) {
+			resolve, report = res, progress
+		})
+
+		var mutex sync.Mutex
+		fractions := []float64{}
+		reported := make(chan struct{}, 4)
+
+		all := promise.All([]any{promise.Resolve(nil), slow}).OnProgress(func(value any) {
+			mutex.Lock()
+			fractions = append(fractions, value.(float64))
+			mutex.Unlock()
+			reported <- struct{}{}
+		})
+
+		<-reported
+		report(0.5)
+		<-reported
+		resolve(nil)
+		all.Wait()
+
+		mutex.Lock()
+		assertEqual(t, fractions[0], 0.5)
+		assertEqual(t, fractions[1], 0.75)
+		mutex.Unlock()
+		done()
+	})
+}
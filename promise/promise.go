@@ -1,6 +1,7 @@
 package promise
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync/atomic"
@@ -13,6 +14,8 @@ func (*noCopy) Unlock() {}
 
 type internalStatus = uint32
 
+var lastPromiseID uint64
+
 const (
 	internalPending   internalStatus = 0
 	internalFulfilled internalStatus = 1
@@ -21,10 +24,21 @@ const (
 
 type Promise struct {
 	noCopy    noCopy
+	id        uint64
 	wait      chan uint32
 	status    internalStatus
 	rejected  error
 	fulfilled any
+	context   context.Context
+	span      *PromiseSpan
+	stack     *asyncStack
+	origin    *hookOrigin
+	observed  uint32
+	labeled   bool
+	progress  promiseProgress
+	lazy      func()
+	started   uint32
+	panicked  bool
 }
 
 type PromiseAggregateError struct {
@@ -52,7 +66,11 @@ const (
 )
 
 func New(fn func(resolve PromiseResolve, reject PromiseReject)) (promise *Promise) {
-	promise = createPromise()
+	return NewWithContext(nil, fn)
+}
+
+func NewWithContext(ctx context.Context, fn func(resolve PromiseResolve, reject PromiseReject)) (promise *Promise) {
+	promise = createPromiseWithContext(ctx, "New")
 
 	fn(func(result any) {
 		assignPromise(promise, result)
@@ -64,25 +82,29 @@ func New(fn func(resolve PromiseResolve, reject PromiseReject)) (promise *Promis
 }
 
 func Resolve(result any) (promise *Promise) {
-	promise = createPromise()
+	promise = createPromise("Resolve", result)
 
-	go assignPromise(promise, result)
+	spawn(promise, func() {
+		assignPromise(promise, result)
+	})
 
 	return promise
 }
 
 func Reject(reason error) (promise *Promise) {
-	promise = createPromise()
+	promise = createPromise("Reject")
 
-	go rejectPromise(promise, reason)
+	spawn(promise, func() {
+		rejectPromise(promise, reason)
+	})
 
 	return promise
 }
 
 func All(arr []any) (promise *Promise) {
-	promise = createPromise()
+	promise = createPromise("All", arr...)
 
-	go func() {
+	spawn(promise, func() {
 		count := uint32(0)
 		length := uint32(len(arr))
 
@@ -91,10 +113,18 @@ func All(arr []any) (promise *Promise) {
 
 		all := make([]any, length)
 
+		progress := &allProgress{
+			promise:   promise,
+			fractions: make([]float64, length),
+		}
+
+		for index := range arr {
+			progress.watch(index, arr[index])
+		}
+
 		for index := range arr {
 			go func(index int) {
 				unpacked, reason := unpackPromise(arr[index])
-				atomic.AddUint32(&count, 1)
 
 				if reason != nil {
 					if atomic.CompareAndSwapUint32(&rejected, 0, 1) {
@@ -104,7 +134,9 @@ func All(arr []any) (promise *Promise) {
 					all[index] = unpacked
 				}
 
-				if atomic.LoadUint32(&count) == length {
+				progress.report(index, 1)
+
+				if atomic.AddUint32(&count, 1) == length {
 					if atomic.LoadUint32(&rejected) == 1 {
 						rejectPromise(promise, err)
 					} else {
@@ -113,15 +145,15 @@ func All(arr []any) (promise *Promise) {
 				}
 			}(index)
 		}
-	}()
+	})
 
 	return promise
 }
 
 func Race(arr []any) (promise *Promise) {
-	promise = createPromise()
+	promise = createPromise("Race", arr...)
 
-	go func() {
+	spawn(promise, func() {
 		count := uint32(0)
 		length := uint32(len(arr))
 
@@ -134,7 +166,6 @@ func Race(arr []any) (promise *Promise) {
 		for index := range arr {
 			go func(index int) {
 				unpacked, reason := unpackPromise(arr[index])
-				atomic.AddUint32(&count, 1)
 
 				if reason != nil {
 					if atomic.LoadUint32(&rejected) == 0 {
@@ -148,7 +179,7 @@ func Race(arr []any) (promise *Promise) {
 					}
 				}
 
-				if atomic.LoadUint32(&count) == length {
+				if atomic.AddUint32(&count, 1) == length {
 					if atomic.LoadUint32(&rejected) == 1 {
 						rejectPromise(promise, err)
 					} else {
@@ -157,15 +188,15 @@ func Race(arr []any) (promise *Promise) {
 				}
 			}(index)
 		}
-	}()
+	})
 
 	return promise
 }
 
 func Any(arr []any) (promise *Promise) {
-	promise = createPromise()
+	promise = createPromise("Any", arr...)
 
-	go func() {
+	spawn(promise, func() {
 		count := uint32(0)
 		length := uint32(len(arr))
 
@@ -174,7 +205,6 @@ func Any(arr []any) (promise *Promise) {
 		for index := range arr {
 			go func(index int) {
 				unpacked, reason := unpackPromise(arr[index])
-				atomic.AddUint32(&count, 1)
 
 				errors[index] = reason
 
@@ -182,7 +212,7 @@ func Any(arr []any) (promise *Promise) {
 					fulfillPromise(promise, unpacked)
 				}
 
-				if atomic.LoadUint32(&count) == length {
+				if atomic.AddUint32(&count, 1) == length {
 					if atomic.LoadUint32(&promise.status) == internalPending {
 						aggregated := []error{}
 
@@ -199,15 +229,15 @@ func Any(arr []any) (promise *Promise) {
 				}
 			}(index)
 		}
-	}()
+	})
 
 	return promise
 }
 
 func AllSettled(arr []any) (promise *Promise) {
-	promise = createPromise()
+	promise = createPromise("AllSettled", arr...)
 
-	go func() {
+	spawn(promise, func() {
 		count := uint32(0)
 		length := uint32(len(arr))
 
@@ -216,7 +246,6 @@ func AllSettled(arr []any) (promise *Promise) {
 		for index := range arr {
 			go func(index int) {
 				unpacked, reason := unpackPromise(arr[index])
-				atomic.AddUint32(&count, 1)
 
 				var status PromiseStatus
 				if reason == nil {
@@ -231,78 +260,92 @@ func AllSettled(arr []any) (promise *Promise) {
 					Value:  unpacked,
 				}
 
-				if atomic.LoadUint32(&count) == length {
+				if atomic.AddUint32(&count, 1) == length {
 					fulfillPromise(promise, settled)
 				}
 			}(index)
 		}
-	}()
+	})
 
 	return promise
 }
 
 func (self *Promise) Then(then PromiseThen) (promise *Promise) {
-	promise = createPromise()
+	promise = createPromise("Then", self)
+	forwardProgress(self, promise)
 
-	go func() {
+	spawn(promise, func() {
 		<-self.wait
 
 		switch atomic.LoadUint32(&self.status) {
 		case internalFulfilled:
-			packed, reason := then(self.fulfilled)
+			packed, reason := invokeHandler(promise, func() (any, error) {
+				return then(self.fulfilled)
+			})
 			resolvePromise(promise, packed, reason)
 		case internalRejected:
 			rejectPromise(promise, self.rejected)
 		}
-	}()
+	})
 
 	return promise
 }
 
 func (self *Promise) Catch(catch PromiseCatch) (promise *Promise) {
-	promise = createPromise()
+	promise = createPromise("Catch", self)
+	forwardProgress(self, promise)
 
-	go func() {
+	spawn(promise, func() {
 		<-self.wait
 
 		switch atomic.LoadUint32(&self.status) {
 		case internalFulfilled:
 			fulfillPromise(promise, self.fulfilled)
 		case internalRejected:
-			packed, reason := catch(self.rejected)
+			packed, reason := invokeHandler(promise, func() (any, error) {
+				return catch(self.rejected)
+			})
 			resolvePromise(promise, packed, reason)
 		}
-	}()
+	})
 
 	return promise
 }
 
 func (self *Promise) ThenCatch(then PromiseThen, catch PromiseCatch) (promise *Promise) {
-	promise = createPromise()
+	promise = createPromise("ThenCatch", self)
+	forwardProgress(self, promise)
 
-	go func() {
+	spawn(promise, func() {
 		<-self.wait
 
 		switch atomic.LoadUint32(&self.status) {
 		case internalFulfilled:
-			packed, reason := then(self.fulfilled)
+			packed, reason := invokeHandler(promise, func() (any, error) {
+				return then(self.fulfilled)
+			})
 			resolvePromise(promise, packed, reason)
 		case internalRejected:
-			packed, reason := catch(self.rejected)
+			packed, reason := invokeHandler(promise, func() (any, error) {
+				return catch(self.rejected)
+			})
 			resolvePromise(promise, packed, reason)
 		}
-	}()
+	})
 
 	return promise
 }
 
 func (self *Promise) Finally(finally PromiseFinally) (promise *Promise) {
-	promise = createPromise()
+	promise = createPromise("Finally", self)
+	forwardProgress(self, promise)
 
-	go func() {
+	spawn(promise, func() {
 		<-self.wait
 
-		err := finally()
+		_, err := invokeHandler(promise, func() (any, error) {
+			return nil, finally()
+		})
 
 		if err != nil {
 			rejectPromise(promise, err)
@@ -314,31 +357,80 @@ func (self *Promise) Finally(finally PromiseFinally) (promise *Promise) {
 				rejectPromise(promise, self.rejected)
 			}
 		}
-	}()
+	})
 
 	return promise
 }
 
 func (self *Promise) Wait() (unpacked any, reason error) {
+	markObserved(self)
 	<-self.wait
 	return self.fulfilled, self.rejected
 }
 
-func createPromise() (promise *Promise) {
+func (self *Promise) Context() context.Context {
+	return self.context
+}
+
+func createPromise(operation string, parents ...any) (promise *Promise) {
+	return createPromiseWithContext(nil, operation, parents...)
+}
+
+func createPromiseWithContext(ctx context.Context, operation string, parents ...any) (promise *Promise) {
 	promise = &Promise{
+		id:        atomic.AddUint64(&lastPromiseID, 1),
 		wait:      make(chan uint32, 0),
 		status:    internalPending,
 		fulfilled: nil,
 		rejected:  nil,
+		context:   inheritContext(ctx, parents),
+		span:      nil,
+		stack:     nil,
+		origin:    nil,
+		observed:  0,
+		labeled:   inheritLabels(ctx, parents),
 	}
 
 	atomic.StoreUint32(&promise.status, internalPending)
 
+	traceCreate(promise, operation, parents)
+	captureStack(promise, operation, parents)
+	hookCreate(promise, operation, parents)
+
+	for _, parent := range parents {
+		if parent, ok := parent.(*Promise); ok {
+			markObserved(parent)
+		}
+	}
+
 	return promise
 }
 
+func inheritContext(ctx context.Context, parents []any) context.Context {
+	if ctx != nil {
+		return ctx
+	}
+
+	for _, parent := range parents {
+		if promise, ok := parent.(*Promise); ok {
+			return promise.context
+		}
+	}
+
+	return context.Background()
+}
+
+func invokeHandler(promise *Promise, handler func() (any, error)) (packed any, reason error) {
+	traceHandlerStart(promise)
+	packed, reason = handler()
+	traceHandlerEnd(promise)
+
+	return packed, reason
+}
+
 func unpackPromise(value any) (result any, reason error) {
 	if promise, ok := value.(*Promise); ok {
+		markObserved(promise)
 		<-promise.wait
 
 		switch atomic.LoadUint32(&promise.status) {
@@ -355,18 +447,29 @@ func unpackPromise(value any) (result any, reason error) {
 func fulfillPromise(promise *Promise, unpacked any) {
 	if atomic.CompareAndSwapUint32(&promise.status, internalPending, internalFulfilled) {
 		promise.fulfilled = unpacked
+		settleProgress(promise)
+		traceSettle(promise)
+		hookSettle(promise)
 		close(promise.wait)
 	}
 }
 
 func rejectPromise(promise *Promise, reason error) {
 	if atomic.CompareAndSwapUint32(&promise.status, internalPending, internalRejected) {
-		promise.rejected = reason
+		promise.rejected = attachStack(promise, reason)
+		settleProgress(promise)
+		traceSettle(promise)
+		hookSettle(promise)
 		close(promise.wait)
+		watchRejection(promise)
 	}
 }
 
 func assignPromise(promise *Promise, packed any) {
+	if adopted, ok := packed.(*Promise); ok && adopted != promise {
+		forwardProgress(adopted, promise)
+	}
+
 	unpacked, reason := unpackPromise(packed)
 	if reason != nil {
 		rejectPromise(promise, reason)
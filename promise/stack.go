@@ -0,0 +1,123 @@
+package promise
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	stackFrames = 16
+	stackLinks  = 64
+)
+
+type asyncStack struct {
+	operation string
+	callers   []uintptr
+	parent    *asyncStack
+}
+
+type PromiseStackError struct {
+	error
+	stack *asyncStack
+}
+
+var longStackTraces uint32
+
+func SetLongStackTraces(enabled bool) {
+	if enabled {
+		atomic.StoreUint32(&longStackTraces, 1)
+	} else {
+		atomic.StoreUint32(&longStackTraces, 0)
+	}
+}
+
+func (self PromiseStackError) Unwrap() error {
+	return self.error
+}
+
+func (self PromiseStackError) Stack() string {
+	builder := strings.Builder{}
+
+	links := 0
+	for current := self.stack; current != nil && links < stackLinks; current = current.parent {
+		links++
+
+		builder.WriteString(current.operation)
+		builder.WriteString("\n")
+
+		frames := runtime.CallersFrames(current.callers)
+		for {
+			frame, more := frames.Next()
+
+			if frame.Function != "" && (!strings.HasPrefix(frame.Function, modulePrefix) || strings.HasSuffix(frame.File, "_test.go")) {
+				builder.WriteString(fmt.Sprintf("\t%s\n\t\t%s:%d\n", frame.Function, frame.File, frame.Line))
+			}
+
+			if !more {
+				break
+			}
+		}
+	}
+
+	return builder.String()
+}
+
+func (self PromiseStackError) Format(state fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if state.Flag('+') {
+			fmt.Fprintf(state, "%+v\n", self.error)
+			io.WriteString(state, "async stack:\n")
+			io.WriteString(state, self.Stack())
+
+			return
+		}
+
+		fallthrough
+	case 's':
+		io.WriteString(state, self.Error())
+	case 'q':
+		fmt.Fprintf(state, "%q", self.Error())
+	}
+}
+
+func captureStack(promise *Promise, operation string, parents []any) {
+	if atomic.LoadUint32(&longStackTraces) == 0 {
+		return
+	}
+
+	callers := make([]uintptr, stackFrames)
+	count := runtime.Callers(4, callers)
+
+	stack := &asyncStack{
+		operation: operation,
+		callers:   callers[:count],
+	}
+
+	for _, parent := range parents {
+		if parent, ok := parent.(*Promise); ok && parent.stack != nil {
+			stack.parent = parent.stack
+			break
+		}
+	}
+
+	promise.stack = stack
+}
+
+func attachStack(promise *Promise, reason error) error {
+	if promise.stack == nil || reason == nil {
+		return reason
+	}
+
+	if wrapped, ok := reason.(PromiseStackError); ok {
+		reason = wrapped.error
+	}
+
+	return PromiseStackError{
+		error: reason,
+		stack: promise.stack,
+	}
+}
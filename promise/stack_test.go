@@ -0,0 +1,33 @@
+package promise_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	promise "github.com/eolme/go-promise/promise"
+)
+
+func TestLongStackTraces(t *testing.T) {
+	promise.SetLongStackTraces(true)
+	defer promise.SetLongStackTraces(false)
+
+	dummyReason := createDummyReason()
+
+	_, reason := promise.Reject(dummyReason).Then(func(result any) (any, error) {
+		return result, nil
+	}).Finally(func() error {
+		return nil
+	}).Wait()
+
+	assertEqual(t, errors.Is(reason, dummyReason), true)
+	assertEqual(t, fmt.Sprintf("%v", reason), dummyReason.Error())
+
+	formatted := fmt.Sprintf("%+v", reason)
+
+	assertEqual(t, strings.Contains(formatted, "Finally\n"), true)
+	assertEqual(t, strings.Contains(formatted, "Then\n"), true)
+	assertEqual(t, strings.Contains(formatted, "Reject\n"), true)
+	assertEqual(t, strings.Contains(formatted, "stack_test.go"), true)
+}
@@ -0,0 +1,193 @@
+package promise
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const modulePrefix = "github.com/eolme/go-promise/"
+
+type PromiseTracer interface {
+	Start(ctx context.Context, span *PromiseSpan) context.Context
+	End(span *PromiseSpan)
+}
+
+type PromiseSpan struct {
+	ID             uint64
+	Parents        []uint64
+	Operation      string
+	Site           string
+	Created        time.Time
+	HandlerStarted time.Time
+	HandlerEnded   time.Time
+	Settled        time.Time
+	Status         PromiseStatus
+	Reason         error
+	tracer         PromiseTracer
+}
+
+type PromiseRecorder struct {
+	mutex sync.Mutex
+	spans []PromiseSpan
+}
+
+type tracerHolder struct {
+	tracer PromiseTracer
+}
+
+type spanContextKey struct{}
+
+var currentTracer atomic.Value
+
+func SetTracer(tracer PromiseTracer) {
+	currentTracer.Store(tracerHolder{
+		tracer: tracer,
+	})
+}
+
+func SpanFromContext(ctx context.Context) *PromiseSpan {
+	if ctx == nil {
+		return nil
+	}
+
+	span, _ := ctx.Value(spanContextKey{}).(*PromiseSpan)
+
+	return span
+}
+
+func NewRecorder() (recorder *PromiseRecorder) {
+	recorder = &PromiseRecorder{
+		spans: []PromiseSpan{},
+	}
+
+	return recorder
+}
+
+func (self *PromiseRecorder) Start(ctx context.Context, _ *PromiseSpan) context.Context {
+	return ctx
+}
+
+func (self *PromiseRecorder) End(span *PromiseSpan) {
+	self.mutex.Lock()
+	self.spans = append(self.spans, *span)
+	self.mutex.Unlock()
+}
+
+func (self *PromiseRecorder) Spans() []PromiseSpan {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return append([]PromiseSpan{}, self.spans...)
+}
+
+func (self *PromiseRecorder) Reset() {
+	self.mutex.Lock()
+	self.spans = []PromiseSpan{}
+	self.mutex.Unlock()
+}
+
+func (self *PromiseSpan) Pending() time.Duration {
+	if self.Settled.IsZero() {
+		return 0
+	}
+
+	return self.Settled.Sub(self.Created)
+}
+
+func (self *PromiseSpan) Handler() time.Duration {
+	if self.HandlerEnded.IsZero() {
+		return 0
+	}
+
+	return self.HandlerEnded.Sub(self.HandlerStarted)
+}
+
+func loadTracer() PromiseTracer {
+	holder, _ := currentTracer.Load().(tracerHolder)
+
+	return holder.tracer
+}
+
+func traceCreate(promise *Promise, operation string, parents []any) {
+	tracer := loadTracer()
+	if tracer == nil {
+		return
+	}
+
+	span := &PromiseSpan{
+		ID:        promise.id,
+		Parents:   parentIDs(parents),
+		Operation: operation,
+		Site:      callerSite(),
+		Created:   time.Now(),
+		tracer:    tracer,
+	}
+
+	if len(span.Parents) == 0 {
+		if parent := SpanFromContext(promise.context); parent != nil {
+			span.Parents = append(span.Parents, parent.ID)
+		}
+	}
+
+	ctx := tracer.Start(promise.context, span)
+	if ctx == nil {
+		ctx = promise.context
+	}
+
+	promise.context = context.WithValue(ctx, spanContextKey{}, span)
+	promise.span = span
+}
+
+func traceHandlerStart(promise *Promise) {
+	if promise.span != nil {
+		promise.span.HandlerStarted = time.Now()
+	}
+}
+
+func traceHandlerEnd(promise *Promise) {
+	if promise.span != nil {
+		promise.span.HandlerEnded = time.Now()
+	}
+}
+
+func traceSettle(promise *Promise) {
+	span := promise.span
+	if span == nil {
+		return
+	}
+
+	span.Settled = time.Now()
+
+	switch atomic.LoadUint32(&promise.status) {
+	case internalFulfilled:
+		span.Status = PromiseStatusFulfilled
+	case internalRejected:
+		span.Status = PromiseStatusRejected
+		span.Reason = promise.rejected
+	}
+
+	span.tracer.End(span)
+}
+
+func callerSite() string {
+	callers := make([]uintptr, 32)
+	count := runtime.Callers(3, callers)
+	frames := runtime.CallersFrames(callers[:count])
+
+	for {
+		frame, more := frames.Next()
+
+		if !strings.HasPrefix(frame.Function, modulePrefix) || strings.HasSuffix(frame.File, "_test.go") {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+
+		if !more {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package promise_test
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+
+	promise "github.com/eolme/go-promise/promise"
+)
+
+func TestTrace(t *testing.T) {
+	recorder := promise.NewRecorder()
+	promise.SetTracer(recorder)
+	defer promise.SetTracer(nil)
+
+	root := promise.NewWithContext(context.Background(), func(resolve promise.PromiseResolve, _ promise.PromiseReject) {
+		resolve(createDummyValue())
+	})
+
+	_, file, line, _ := runtime.Caller(0)
+	derived := root.Then(func(result any) (any, error) {
+		return nil, createDummyReason()
+	})
+
+	derived.Wait()
+
+	rootSpan := promise.SpanFromContext(root.Context())
+	derivedSpan := promise.SpanFromContext(derived.Context())
+
+	assertEqual(t, rootSpan.Operation, "New")
+	assertEqual(t, derivedSpan.Operation, "Then")
+	assertEqual(t, derivedSpan.Parents[0], rootSpan.ID)
+	assertEqual(t, derivedSpan.Site, fmt.Sprintf("%s:%d", file, line+1))
+
+	recorded := map[uint64]promise.PromiseSpan{}
+	for _, span := range recorder.Spans() {
+		recorded[span.ID] = span
+	}
+
+	assertEqual(t, recorded[rootSpan.ID].Status, promise.PromiseStatusFulfilled)
+	assertEqual(t, recorded[derivedSpan.ID].Status, promise.PromiseStatusRejected)
+	assertEqual(t, recorded[derivedSpan.ID].HandlerEnded.IsZero(), false)
+}
@@ -0,0 +1,86 @@
+package promise
+
+import (
+	"errors"
+	"fmt"
+)
+
+type PromisePanicError struct {
+	error
+	Value any
+}
+
+func (self PromisePanicError) Unwrap() error {
+	return self.error
+}
+
+func WithResolvers() (promise *Promise, resolve PromiseResolve, reject PromiseReject) {
+	promise = createPromise("WithResolvers")
+
+	resolve = func(result any) {
+		assignPromise(promise, result)
+	}
+
+	reject = func(reason error) {
+		rejectPromise(promise, reason)
+	}
+
+	return promise, resolve, reject
+}
+
+func Try(fn func() (any, error)) (promise *Promise) {
+	promise = createPromise("Try")
+
+	packed, reason := invokeHandler(promise, func() (packed any, reason error) {
+		defer func() {
+			if value := recover(); value != nil {
+				promise.panicked = true
+				packed, reason = nil, recoverPanic(value)
+			}
+		}()
+
+		return fn()
+	})
+
+	if _, ok := packed.(*Promise); ok && reason == nil {
+		spawn(promise, func() {
+			resolvePromise(promise, packed, nil)
+		})
+	} else {
+		resolvePromise(promise, packed, reason)
+	}
+
+	return promise
+}
+
+func recoverPanic(value any) error {
+	if err, ok := value.(error); ok {
+		return err
+	}
+
+	if str, ok := value.(string); ok {
+		return PromisePanicError{
+			error: errors.New(str),
+			Value: value,
+		}
+	}
+
+	return PromisePanicError{
+		error: errors.New(fmt.Sprintf("panic handled with %p", &value)),
+		Value: value,
+	}
+}
+
+func Go(fn func() (any, error)) (promise *Promise) {
+	return New(func(resolve PromiseResolve, reject PromiseReject) {
+		go func() {
+			packed, reason := fn()
+
+			if reason != nil {
+				reject(reason)
+			} else {
+				resolve(packed)
+			}
+		}()
+	})
+}
@@ -0,0 +1,118 @@
+package promise_test
+
+import (
+	"errors"
+	"testing"
+
+	promise "github.com/eolme/go-promise/promise"
+)
+
+func TestWithResolvers(t *testing.T) {
+	testPrepare(t)
+
+	testAsync(t, "settles through the returned resolve", func(t *testing.T, done func()) {
+		dummyValue := createDummyValue()
+
+		instance, resolve, _ := promise.WithResolvers()
+		resolve(dummyValue)
+
+		result, reason := instance.Wait()
+
+		assertEqual(t, result, dummyValue)
+		assertEqual(t, reason, nil)
+		done()
+	})
+
+	testAsync(t, "settles through the returned reject", func(t *testing.T, done func()) {
+		dummyReason := createDummyReason()
+
+		instance, _, reject := promise.WithResolvers()
+		reject(dummyReason)
+
+		_, reason := instance.Wait()
+
+		assertEqual(t, reason, dummyReason)
+		done()
+	})
+}
+
+func TestTry(t *testing.T) {
+	testPrepare(t)
+
+	testAsync(t, "runs the function synchronously", func(t *testing.T, done func()) {
+		called := false
+
+		promise.Try(func() (any, error) {
+			called = true
+			return nil, nil
+		})
+
+		assertEqual(t, called, true)
+		done()
+	})
+
+	testAsync(t, "rejects with the returned error", func(t *testing.T, done func()) {
+		dummyReason := createDummyReason()
+
+		_, reason := promise.Try(func() (any, error) {
+			return nil, dummyReason
+		}).Wait()
+
+		assertEqual(t, reason, dummyReason)
+		done()
+	})
+
+	testAsync(t, "rejects with a recovered panic", func(t *testing.T, done func()) {
+		_, reason := promise.Try(func() (any, error) {
+			panic("something went wrong")
+		}).Wait()
+
+		var panicked promise.PromisePanicError
+		assertEqual(t, errors.As(reason, &panicked), true)
+		assertEqual(t, panicked.Value, "something went wrong")
+		done()
+	})
+
+	testAsync(t, "adopts a returned promise", func(t *testing.T, done func()) {
+		dummyValue := createDummyValue()
+		pending, resolve, _ := promise.WithResolvers()
+
+		instance := promise.Try(func() (any, error) {
+			return pending, nil
+		})
+
+		resolve(dummyValue)
+		result, reason := instance.Wait()
+
+		assertEqual(t, result, dummyValue)
+		assertEqual(t, reason, nil)
+		done()
+	})
+}
+
+func TestGo(t *testing.T) {
+	testPrepare(t)
+
+	testAsync(t, "resolves with the returned value", func(t *testing.T, done func()) {
+		dummyValue := createDummyValue()
+
+		result, reason := promise.Go(func() (any, error) {
+			return dummyValue, nil
+		}).Wait()
+
+		assertEqual(t, result, dummyValue)
+		assertEqual(t, reason, nil)
+		done()
+	})
+
+	testAsync(t, "rejects with the returned error", func(t *testing.T, done func()) {
+		dummyReason := createDummyReason()
+
+		_, reason := promise.Go(func() (any, error) {
+			return nil, dummyReason
+		}).Wait()
+
+		assertEqual(t, reason, dummyReason)
+		done()
+	})
+}
@@ -0,0 +1,168 @@
+package promisify
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	panics "github.com/eolme/go-promise/panics"
+	promise "github.com/eolme/go-promise/promise"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+func Func0[T any](fn func() (T, error)) func() *promise.Promise {
+	return func() *promise.Promise {
+		return panics.AsyncPanic(func() (any, error) {
+			return fn()
+		})
+	}
+}
+
+func Func1[A, T any](fn func(A) (T, error)) func(A) *promise.Promise {
+	return func(a A) *promise.Promise {
+		return panics.AsyncPanic(func() (any, error) {
+			return fn(a)
+		})
+	}
+}
+
+func Func2[A, B, T any](fn func(A, B) (T, error)) func(A, B) *promise.Promise {
+	return func(a A, b B) *promise.Promise {
+		return panics.AsyncPanic(func() (any, error) {
+			return fn(a, b)
+		})
+	}
+}
+
+func Func3[A, B, C, T any](fn func(A, B, C) (T, error)) func(A, B, C) *promise.Promise {
+	return func(a A, b B, c C) *promise.Promise {
+		return panics.AsyncPanic(func() (any, error) {
+			return fn(a, b, c)
+		})
+	}
+}
+
+func Any(fn any) func(args ...any) *promise.Promise {
+	value := reflect.ValueOf(fn)
+	kind := value.Type()
+
+	if kind.Kind() != reflect.Func {
+		panic(fmt.Sprintf("Promisify expects a function, received %T", fn))
+	}
+
+	if kind.NumOut() == 0 || kind.Out(kind.NumOut()-1) != errorType {
+		panic(fmt.Sprintf("Promisify expects a function returning error last, received %T", fn))
+	}
+
+	return func(args ...any) *promise.Promise {
+		return panics.AsyncPanic(func() (any, error) {
+			in, reason := prepareArguments(kind, args)
+			if reason != nil {
+				return nil, reason
+			}
+
+			var out []reflect.Value
+			if kind.IsVariadic() {
+				out = value.CallSlice(in)
+			} else {
+				out = value.Call(in)
+			}
+
+			last := len(out) - 1
+			if !out[last].IsNil() {
+				return nil, out[last].Interface().(error)
+			}
+
+			switch last {
+			case 0:
+				return nil, nil
+			case 1:
+				return out[0].Interface(), nil
+			}
+
+			results := make([]any, last)
+			for index := range results {
+				results[index] = out[index].Interface()
+			}
+
+			return results, nil
+		})
+	}
+}
+
+func Callback[T any](fn func(callback func(result T, reason error))) *promise.Promise {
+	return panics.AsyncPanic(func() (any, error) {
+		return promise.New(func(resolve promise.PromiseResolve, reject promise.PromiseReject) {
+			fn(func(result T, reason error) {
+				if reason != nil {
+					reject(reason)
+				} else {
+					resolve(result)
+				}
+			})
+		}), nil
+	})
+}
+
+func prepareArguments(kind reflect.Type, args []any) (in []reflect.Value, reason error) {
+	count := kind.NumIn()
+
+	if kind.IsVariadic() {
+		if len(args) < count-1 {
+			return nil, errors.New(fmt.Sprintf("Expected at least %d arguments, received %d", count-1, len(args)))
+		}
+	} else if len(args) != count {
+		return nil, errors.New(fmt.Sprintf("Expected %d arguments, received %d", count, len(args)))
+	}
+
+	in = make([]reflect.Value, count)
+
+	for index := 0; index < count; index++ {
+		expected := kind.In(index)
+
+		if kind.IsVariadic() && index == count-1 {
+			rest := reflect.MakeSlice(expected, 0, len(args)-index)
+
+			for _, arg := range args[index:] {
+				converted, reason := convertArgument(expected.Elem(), arg)
+				if reason != nil {
+					return nil, reason
+				}
+
+				rest = reflect.Append(rest, converted)
+			}
+
+			in[index] = rest
+			break
+		}
+
+		converted, reason := convertArgument(expected, args[index])
+		if reason != nil {
+			return nil, reason
+		}
+
+		in[index] = converted
+	}
+
+	return in, nil
+}
+
+func convertArgument(expected reflect.Type, arg any) (reflect.Value, error) {
+	if arg == nil {
+		switch expected.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+			return reflect.Zero(expected), nil
+		}
+
+		return reflect.Value{}, errors.New(fmt.Sprintf("Cannot use nil as %s", expected))
+	}
+
+	value := reflect.ValueOf(arg)
+
+	if value.Type().AssignableTo(expected) {
+		return value, nil
+	}
+
+	return reflect.Value{}, errors.New(fmt.Sprintf("Cannot use %s as %s", value.Type(), expected))
+}
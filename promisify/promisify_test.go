@@ -0,0 +1,202 @@
+package promisify_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	promisify "github.com/eolme/go-promise/promisify"
+)
+
+func assertEqual(t *testing.T, actual any, expected any) {
+	if actual == expected {
+		t.Logf("Assertion success: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	} else {
+		t.Errorf("Assertion fail: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	}
+}
+
+func assertError(t *testing.T, actual error, expected string) {
+	if actual == nil {
+		t.Errorf("Assertion fail: expected error `%s`, received nil", expected)
+		return
+	}
+
+	reason := actual.Error()
+	if strings.Contains(reason, expected) {
+		t.Logf("Assertion success: expected error `%s`, received `%s`", expected, reason)
+	} else {
+		t.Errorf("Assertion fail: expected error `%s`, received `%s`", expected, reason)
+	}
+}
+
+func TestFunc(t *testing.T) {
+	t.Parallel()
+
+	result, reason := promisify.Func0(func() (int, error) {
+		return 0, nil
+	})().Wait()
+	assertEqual(t, result, 0)
+	assertEqual(t, reason, nil)
+
+	result, _ = promisify.Func1(func(a int) (int, error) {
+		return a, nil
+	})(1).Wait()
+	assertEqual(t, result, 1)
+
+	result, _ = promisify.Func2(func(a int, b int) (int, error) {
+		return a + b, nil
+	})(1, 2).Wait()
+	assertEqual(t, result, 3)
+
+	result, _ = promisify.Func3(func(a int, b int, c string) (string, error) {
+		return strings.Repeat(c, a+b), nil
+	})(1, 2, "a").Wait()
+	assertEqual(t, result, "aaa")
+
+	expected := errors.New("unavailable")
+	_, reason = promisify.Func1(func(a int) (int, error) {
+		return 0, expected
+	})(1).Wait()
+	assertEqual(t, reason, expected)
+}
+
+func TestFuncPanic(t *testing.T) {
+	t.Parallel()
+
+	_, reason := promisify.Func0(func() (int, error) {
+		panic("something went wrong")
+	})().Wait()
+
+	assertError(t, reason, "something went wrong")
+}
+
+func TestAny(t *testing.T) {
+	t.Parallel()
+
+	result, reason := promisify.Any(func() error {
+		return nil
+	})().Wait()
+	assertEqual(t, result, nil)
+	assertEqual(t, reason, nil)
+
+	result, _ = promisify.Any(func(a int) (int, error) {
+		return a * 2, nil
+	})(2).Wait()
+	assertEqual(t, result, 4)
+
+	result, _ = promisify.Any(func(a int, b string) (int, string, error) {
+		return a, b, nil
+	})(1, "b").Wait()
+	assertEqual(t, result.([]any)[0], 1)
+	assertEqual(t, result.([]any)[1], "b")
+
+	result, _ = promisify.Any(func(a int, b int, c int) (int, error) {
+		return a + b + c, nil
+	})(1, 2, 3).Wait()
+	assertEqual(t, result, 6)
+
+	expected := errors.New("unavailable")
+	_, reason = promisify.Any(func() (int, error) {
+		return 0, expected
+	})().Wait()
+	assertEqual(t, reason, expected)
+}
+
+func TestAnyVariadic(t *testing.T) {
+	t.Parallel()
+
+	sum := promisify.Any(func(base int, rest ...int) (int, error) {
+		for _, value := range rest {
+			base += value
+		}
+
+		return base, nil
+	})
+
+	result, _ := sum(1).Wait()
+	assertEqual(t, result, 1)
+
+	result, _ = sum(1, 2, 3).Wait()
+	assertEqual(t, result, 6)
+
+	_, reason := sum().Wait()
+	assertError(t, reason, "Expected at least 1 arguments, received 0")
+}
+
+func TestAnyNilArguments(t *testing.T) {
+	t.Parallel()
+
+	length := promisify.Any(func(values []int, named *string) (int, error) {
+		return len(values), nil
+	})
+
+	result, reason := length(nil, nil).Wait()
+	assertEqual(t, result, 0)
+	assertEqual(t, reason, nil)
+
+	_, reason = promisify.Any(func(a int) (int, error) {
+		return a, nil
+	})(nil).Wait()
+	assertError(t, reason, "Cannot use nil as int")
+}
+
+func TestAnyArgumentErrors(t *testing.T) {
+	t.Parallel()
+
+	double := promisify.Any(func(a int) (int, error) {
+		return a * 2, nil
+	})
+
+	_, reason := double().Wait()
+	assertError(t, reason, "Expected 1 arguments, received 0")
+
+	_, reason = double(1, 2).Wait()
+	assertError(t, reason, "Expected 1 arguments, received 2")
+
+	_, reason = double("1").Wait()
+	assertError(t, reason, "Cannot use string as int")
+}
+
+func TestAnyPanic(t *testing.T) {
+	t.Parallel()
+
+	_, reason := promisify.Any(func() error {
+		panic("something went wrong")
+	})().Wait()
+
+	assertError(t, reason, "something went wrong")
+}
+
+func TestAnyRejectsNonFunctions(t *testing.T) {
+	t.Parallel()
+
+	expectPanic := func(name string, fn any) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Assertion fail: expected %s to panic", name)
+			}
+		}()
+
+		promisify.Any(fn)
+	}
+
+	expectPanic("a non-function", 1)
+	expectPanic("a function without error", func() int { return 0 })
+}
+
+func TestCallback(t *testing.T) {
+	t.Parallel()
+
+	result, reason := promisify.Callback(func(callback func(result int, reason error)) {
+		go callback(1, nil)
+	}).Wait()
+	assertEqual(t, result, 1)
+	assertEqual(t, reason, nil)
+
+	expected := errors.New("unavailable")
+	_, reason = promisify.Callback(func(callback func(result int, reason error)) {
+		callback(0, expected)
+	}).Wait()
+	assertEqual(t, reason, expected)
+}
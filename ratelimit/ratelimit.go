@@ -0,0 +1,167 @@
+package ratelimit
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	async "github.com/eolme/go-promise/async"
+	promise "github.com/eolme/go-promise/promise"
+)
+
+type ThrottleOptions struct {
+	NoLeading  bool
+	NoTrailing bool
+}
+
+type shared struct {
+	promise *promise.Promise
+	resolve promise.PromiseResolve
+}
+
+func createShared() (pending *shared) {
+	pending = &shared{}
+
+	pending.promise = promise.New(func(resolve promise.PromiseResolve, _ promise.PromiseReject) {
+		pending.resolve = resolve
+	})
+
+	return pending
+}
+
+func Wrap(fn async.AsyncFunction, rate float64, burst int) func() *promise.Promise {
+	if rate <= 0 {
+		panic(fmt.Sprintf("Rate limit expects a positive rate, received %v", rate))
+	}
+
+	mutex := sync.Mutex{}
+
+	if burst < 1 {
+		burst = 1
+	}
+
+	tokens := float64(burst)
+	last := time.Now()
+
+	return func() *promise.Promise {
+		mutex.Lock()
+
+		now := time.Now()
+
+		tokens += now.Sub(last).Seconds() * rate
+		if tokens > float64(burst) {
+			tokens = float64(burst)
+		}
+		last = now
+
+		tokens--
+
+		wait := time.Duration(0)
+		if tokens < 0 {
+			wait = time.Duration(-tokens / rate * float64(time.Second))
+		}
+
+		mutex.Unlock()
+
+		return promise.New(func(resolve promise.PromiseResolve, _ promise.PromiseReject) {
+			time.AfterFunc(wait, func() {
+				resolve(async.Async(fn))
+			})
+		})
+	}
+}
+
+func Debounce(fn async.AsyncFunction, wait time.Duration) func() *promise.Promise {
+	mutex := sync.Mutex{}
+	var pending *shared
+	var timer *time.Timer
+
+	return func() *promise.Promise {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if pending == nil {
+			pending = createShared()
+		}
+
+		current := pending
+
+		if timer != nil {
+			timer.Stop()
+		}
+
+		timer = time.AfterFunc(wait, func() {
+			mutex.Lock()
+			if pending != current {
+				mutex.Unlock()
+				return
+			}
+			pending = nil
+			mutex.Unlock()
+
+			current.resolve(async.Async(fn))
+		})
+
+		return current.promise
+	}
+}
+
+func Throttle(fn async.AsyncFunction, interval time.Duration, options ThrottleOptions) func() *promise.Promise {
+	mutex := sync.Mutex{}
+	var pending *shared
+	var last *promise.Promise
+	var ran time.Time
+
+	schedule := func(wait time.Duration) {
+		current := createShared()
+		pending = current
+
+		time.AfterFunc(wait, func() {
+			mutex.Lock()
+			pending = nil
+			ran = time.Now()
+			last = current.promise
+			mutex.Unlock()
+
+			current.resolve(async.Async(fn))
+		})
+	}
+
+	return func() *promise.Promise {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if pending != nil {
+			return pending.promise
+		}
+
+		now := time.Now()
+		elapsed := now.Sub(ran)
+
+		if ran.IsZero() || elapsed >= interval {
+			if !options.NoLeading {
+				ran = now
+				last = async.Async(fn)
+
+				return last
+			}
+
+			if options.NoTrailing {
+				return promise.Resolve(nil)
+			}
+
+			ran = now
+			schedule(interval)
+
+			return pending.promise
+		}
+
+		if options.NoTrailing {
+			return last
+		}
+
+		schedule(interval - elapsed)
+
+		return pending.promise
+	}
+}
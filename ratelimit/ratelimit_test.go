@@ -0,0 +1,154 @@
+package ratelimit_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	promise "github.com/eolme/go-promise/promise"
+	ratelimit "github.com/eolme/go-promise/ratelimit"
+)
+
+func assertEqual(t *testing.T, actual any, expected any) {
+	if actual == expected {
+		t.Logf("Assertion success: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	} else {
+		t.Errorf("Assertion fail: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	}
+}
+
+type counter struct {
+	calls uint32
+}
+
+func (self *counter) call() (any, error) {
+	return atomic.AddUint32(&self.calls, 1), nil
+}
+
+func (self *counter) load() uint32 {
+	return atomic.LoadUint32(&self.calls)
+}
+
+func waitAll(promises ...*promise.Promise) {
+	for _, current := range promises {
+		current.Wait()
+	}
+}
+
+func TestWrap(t *testing.T) {
+	t.Parallel()
+
+	var mutex sync.Mutex
+	started := []time.Time{}
+
+	limited := ratelimit.Wrap(func() (any, error) {
+		mutex.Lock()
+		started = append(started, time.Now())
+		mutex.Unlock()
+
+		return nil, nil
+	}, 20, 2)
+
+	now := time.Now()
+	waitAll(limited(), limited(), limited())
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	assertEqual(t, len(started), 3)
+
+	late := 0
+	for _, at := range started {
+		if at.Sub(now) >= 40*time.Millisecond {
+			late++
+		}
+	}
+
+	assertEqual(t, late, 1)
+}
+
+func TestWrapRejectsNonPositiveRate(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		assertEqual(t, recover() != nil, true)
+	}()
+
+	ratelimit.Wrap(func() (any, error) {
+		return nil, nil
+	}, 0, 1)
+}
+
+func TestDebounce(t *testing.T) {
+	t.Parallel()
+
+	calls := &counter{}
+	debounced := ratelimit.Debounce(calls.call, 20*time.Millisecond)
+
+	first := debounced()
+	second := debounced()
+	third := debounced()
+
+	result, _ := third.Wait()
+
+	assertEqual(t, first, second)
+	assertEqual(t, second, third)
+	assertEqual(t, result, uint32(1))
+	assertEqual(t, calls.load(), uint32(1))
+
+	result, _ = debounced().Wait()
+	assertEqual(t, result, uint32(2))
+}
+
+func TestThrottle(t *testing.T) {
+	t.Parallel()
+
+	calls := &counter{}
+	throttled := ratelimit.Throttle(calls.call, 30*time.Millisecond, ratelimit.ThrottleOptions{})
+
+	leading, _ := throttled().Wait()
+	assertEqual(t, leading, uint32(1))
+
+	second := throttled()
+	third := throttled()
+	assertEqual(t, second, third)
+
+	trailing, _ := third.Wait()
+	assertEqual(t, trailing, uint32(2))
+	assertEqual(t, calls.load(), uint32(2))
+}
+
+func TestThrottleNoTrailing(t *testing.T) {
+	t.Parallel()
+
+	calls := &counter{}
+	throttled := ratelimit.Throttle(calls.call, time.Hour, ratelimit.ThrottleOptions{
+		NoTrailing: true,
+	})
+
+	first := throttled()
+	second := throttled()
+	first.Wait()
+
+	assertEqual(t, first, second)
+	assertEqual(t, calls.load(), uint32(1))
+}
+
+func TestThrottleNoLeading(t *testing.T) {
+	t.Parallel()
+
+	calls := &counter{}
+	throttled := ratelimit.Throttle(calls.call, 20*time.Millisecond, ratelimit.ThrottleOptions{
+		NoLeading: true,
+	})
+
+	first := throttled()
+	assertEqual(t, calls.load(), uint32(0))
+
+	second := throttled()
+	assertEqual(t, first, second)
+
+	result, _ := second.Wait()
+	assertEqual(t, result, uint32(1))
+}
@@ -0,0 +1,131 @@
+package saga
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	async "github.com/eolme/go-promise/async"
+	panics "github.com/eolme/go-promise/panics"
+	promise "github.com/eolme/go-promise/promise"
+)
+
+type SagaCompensation func(result any) error
+
+type SagaCompensationError struct {
+	Step   string
+	Reason error
+}
+
+type SagaError struct {
+	Step          string
+	Reason        error
+	Compensations []SagaCompensationError
+}
+
+type step struct {
+	name       string
+	fn         async.AsyncFunction
+	compensate SagaCompensation
+}
+
+type Saga struct {
+	mutex sync.Mutex
+	steps []step
+}
+
+func New() (saga *Saga) {
+	saga = &Saga{
+		steps: []step{},
+	}
+
+	return saga
+}
+
+func (self *Saga) Step(name string, fn async.AsyncFunction, compensate SagaCompensation) *Saga {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.steps = append(self.steps, step{
+		name:       name,
+		fn:         fn,
+		compensate: compensate,
+	})
+
+	return self
+}
+
+func (self *Saga) Run() *promise.Promise {
+	self.mutex.Lock()
+	steps := append([]step{}, self.steps...)
+	self.mutex.Unlock()
+
+	return async.Async(func() (any, error) {
+		results := make([]any, 0, len(steps))
+
+		for index, current := range steps {
+			result, reason := panics.AsyncPanic(current.fn).Wait()
+
+			if reason != nil {
+				return nil, SagaError{
+					Step:          current.name,
+					Reason:        reason,
+					Compensations: compensate(steps[:index], results),
+				}
+			}
+
+			results = append(results, result)
+		}
+
+		return results, nil
+	})
+}
+
+func compensate(steps []step, results []any) (failures []SagaCompensationError) {
+	failures = []SagaCompensationError{}
+
+	for index := len(steps) - 1; index >= 0; index-- {
+		current := steps[index]
+		if current.compensate == nil {
+			continue
+		}
+
+		result := results[index]
+		_, reason := panics.AsyncPanic(func() (any, error) {
+			return nil, current.compensate(result)
+		}).Wait()
+
+		if reason != nil {
+			failures = append(failures, SagaCompensationError{
+				Step:   current.name,
+				Reason: reason,
+			})
+		}
+	}
+
+	return failures
+}
+
+func (self SagaCompensationError) Error() string {
+	return fmt.Sprintf("Compensation of step %s failed: %s", self.Step, self.Reason)
+}
+
+func (self SagaCompensationError) Unwrap() error {
+	return self.Reason
+}
+
+func (self SagaError) Error() string {
+	messages := []string{
+		fmt.Sprintf("Saga step %s failed: %s", self.Step, self.Reason),
+	}
+
+	for _, failure := range self.Compensations {
+		messages = append(messages, failure.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func (self SagaError) Unwrap() error {
+	return self.Reason
+}
@@ -0,0 +1,117 @@
+package saga_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	saga "github.com/eolme/go-promise/saga"
+)
+
+func assertEqual(t *testing.T, actual any, expected any) {
+	if actual == expected {
+		t.Logf("Assertion success: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	} else {
+		t.Errorf("Assertion fail: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	}
+}
+
+type journal struct {
+	mutex   sync.Mutex
+	entries []string
+}
+
+func (self *journal) step(name string) func() (any, error) {
+	return func() (any, error) {
+		self.record("run " + name)
+		return name, nil
+	}
+}
+
+func (self *journal) compensate(result any) error {
+	self.record("undo " + result.(string))
+	return nil
+}
+
+func (self *journal) record(entry string) {
+	self.mutex.Lock()
+	self.entries = append(self.entries, entry)
+	self.mutex.Unlock()
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	log := &journal{}
+
+	result, reason := saga.New().
+		Step("a", log.step("a"), log.compensate).
+		Step("b", log.step("b"), log.compensate).
+		Run().
+		Wait()
+
+	assertEqual(t, reason, nil)
+	assertEqual(t, len(result.([]any)), 2)
+	assertEqual(t, result.([]any)[1], "b")
+	assertEqual(t, len(log.entries), 2)
+}
+
+func TestCompensatesInReverseOrder(t *testing.T) {
+	t.Parallel()
+
+	log := &journal{}
+	expected := errors.New("unavailable")
+
+	_, reason := saga.New().
+		Step("a", log.step("a"), log.compensate).
+		Step("b", log.step("b"), nil).
+		Step("c", log.step("c"), log.compensate).
+		Step("d", func() (any, error) {
+			return nil, expected
+		}, log.compensate).
+		Run().
+		Wait()
+
+	var failed saga.SagaError
+	assertEqual(t, errors.As(reason, &failed), true)
+	assertEqual(t, failed.Step, "d")
+	assertEqual(t, errors.Is(reason, expected), true)
+	assertEqual(t, len(failed.Compensations), 0)
+
+	order := []string{"run a", "run b", "run c", "undo c", "undo a"}
+
+	assertEqual(t, len(log.entries), len(order))
+	for index := range order {
+		assertEqual(t, log.entries[index], order[index])
+	}
+}
+
+func TestCollectsCompensationFailures(t *testing.T) {
+	t.Parallel()
+
+	log := &journal{}
+	expected := errors.New("cannot undo")
+
+	_, reason := saga.New().
+		Step("a", log.step("a"), log.compensate).
+		Step("b", log.step("b"), func(result any) error {
+			return expected
+		}).
+		Step("c", log.step("c"), func(result any) error {
+			panic("something went wrong")
+		}).
+		Step("d", func() (any, error) {
+			panic("step failed")
+		}, log.compensate).
+		Run().
+		Wait()
+
+	var failed saga.SagaError
+	assertEqual(t, errors.As(reason, &failed), true)
+	assertEqual(t, failed.Step, "d")
+	assertEqual(t, len(failed.Compensations), 2)
+	assertEqual(t, failed.Compensations[0].Step, "c")
+	assertEqual(t, failed.Compensations[1].Step, "b")
+	assertEqual(t, failed.Compensations[1].Reason, expected)
+	assertEqual(t, log.entries[len(log.entries)-1], "undo a")
+}
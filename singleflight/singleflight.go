@@ -0,0 +1,65 @@
+package singleflight
+
+import (
+	"sync"
+
+	promise "github.com/eolme/go-promise/promise"
+)
+
+type call struct {
+	promise *promise.Promise
+}
+
+type Group[K comparable] struct {
+	mutex sync.Mutex
+	calls map[K]*call
+}
+
+func New[K comparable]() (group *Group[K]) {
+	group = &Group[K]{
+		calls: map[K]*call{},
+	}
+
+	return group
+}
+
+func (self *Group[K]) Do(key K, fn func() (any, error)) (shared *promise.Promise, origin bool) {
+	self.mutex.Lock()
+
+	if self.calls == nil {
+		self.calls = map[K]*call{}
+	}
+
+	if current, ok := self.calls[key]; ok {
+		self.mutex.Unlock()
+		return current.promise, false
+	}
+
+	current := &call{
+		promise: promise.Go(fn),
+	}
+	self.calls[key] = current
+
+	self.mutex.Unlock()
+
+	go func() {
+		current.promise.Wait()
+		self.forget(key, current)
+	}()
+
+	return current.promise, true
+}
+
+func (self *Group[K]) Forget(key K) {
+	self.mutex.Lock()
+	delete(self.calls, key)
+	self.mutex.Unlock()
+}
+
+func (self *Group[K]) forget(key K, expected *call) {
+	self.mutex.Lock()
+	if current, ok := self.calls[key]; ok && current == expected {
+		delete(self.calls, key)
+	}
+	self.mutex.Unlock()
+}
@@ -0,0 +1,91 @@
+package singleflight_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	promise "github.com/eolme/go-promise/promise"
+	singleflight "github.com/eolme/go-promise/singleflight"
+)
+
+func assertEqual(t *testing.T, actual any, expected any) {
+	if actual == expected {
+		t.Logf("Assertion success: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	} else {
+		t.Errorf("Assertion fail: expected (%T)%v, received (%T)%v", expected, expected, actual, actual)
+	}
+}
+
+func TestDo(t *testing.T) {
+	t.Parallel()
+
+	group := singleflight.New[string]()
+	calls := uint32(0)
+	origins := uint32(0)
+
+	wait := sync.WaitGroup{}
+	promises := make([]*promise.Promise, 10)
+
+	for index := range promises {
+		wait.Add(1)
+
+		go func(index int) {
+			defer wait.Done()
+
+			shared, origin := group.Do("key", func() (any, error) {
+				atomic.AddUint32(&calls, 1)
+				time.Sleep(50 * time.Millisecond)
+
+				return "value", nil
+			})
+
+			if origin {
+				atomic.AddUint32(&origins, 1)
+			}
+
+			promises[index] = shared
+		}(index)
+	}
+
+	wait.Wait()
+
+	for _, shared := range promises {
+		result, reason := shared.Wait()
+		assertEqual(t, result, "value")
+		assertEqual(t, reason, nil)
+	}
+
+	assertEqual(t, atomic.LoadUint32(&calls), uint32(1))
+	assertEqual(t, atomic.LoadUint32(&origins), uint32(1))
+}
+
+func TestForget(t *testing.T) {
+	t.Parallel()
+
+	group := singleflight.New[string]()
+	release := make(chan struct{})
+
+	first, origin := group.Do("key", func() (any, error) {
+		<-release
+
+		return 1, nil
+	})
+	assertEqual(t, origin, true)
+
+	group.Forget("key")
+
+	second, origin := group.Do("key", func() (any, error) {
+		return 2, nil
+	})
+	assertEqual(t, origin, true)
+
+	close(release)
+
+	result, _ := first.Wait()
+	assertEqual(t, result, 1)
+
+	result, _ = second.Wait()
+	assertEqual(t, result, 2)
+}